@@ -0,0 +1,59 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// maxPrivilegedPort is the highest port reserved for privileged services
+const maxPrivilegedPort = 1023
+
+// ErrInvalidAddr is matched by every error ValidateAddr returns
+var ErrInvalidAddr = errors.New("invalid listen address")
+
+// ValidateAddr reports whether addr is a well-formed host:port listen
+// address on an unprivileged port. The host may be empty to listen on all
+// interfaces, and port 0 picks a free port.
+func ValidateAddr(addr string) error {
+	return validateAddr(addr, false)
+}
+
+// validateAddr is ValidateAddr with privileged ports optionally allowed
+func validateAddr(addr string, allowPrivileged bool) error {
+	host, portStr, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrInvalidAddr, addr, err)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil || port < 0 || port > 65535 {
+		return fmt.Errorf("%w %q: port %q is not a number between 0 and 65535", ErrInvalidAddr, addr, portStr)
+	}
+	if host != "" && net.ParseIP(host) == nil && !validHostname(host) {
+		return fmt.Errorf("%w %q: malformed host %q", ErrInvalidAddr, addr, host)
+	}
+	if port > 0 && port <= maxPrivilegedPort && !allowPrivileged {
+		return fmt.Errorf("%w %q: port %d is privileged (use a port above %d or allow privileged ports)", ErrInvalidAddr, addr, port, maxPrivilegedPort)
+	}
+	return nil
+}
+
+// validHostname reports whether host is a syntactically valid DNS name
+func validHostname(host string) bool {
+	if len(host) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(strings.TrimSuffix(host, "."), ".") {
+		if label == "" || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateAddr(t *testing.T) {
+	tests := []struct {
+		name    string
+		addr    string
+		wantErr string
+	}{
+		{"all interfaces", ":8080", ""},
+		{"ipv4", "127.0.0.1:8080", ""},
+		{"ipv6", "[::1]:8080", ""},
+		{"hostname", "localhost:9090", ""},
+		{"fqdn", "greeter.example.com:8443", ""},
+		{"any port", "127.0.0.1:0", ""},
+		{"highest port", ":65535", ""},
+		{"first unprivileged port", ":1024", ""},
+		{"missing port", "localhost", "missing port"},
+		{"empty", "", "missing port"},
+		{"named port", ":http", "not a number"},
+		{"port out of range", ":65536", "not a number"},
+		{"negative port", ":-1", "not a number"},
+		{"unbracketed ipv6", "::1:8080", "too many colons"},
+		{"malformed host", "bad_host!:8080", "malformed host"},
+		{"leading hyphen", "-host:8080", "malformed host"},
+		{"privileged port", ":80", "port 80 is privileged"},
+		{"highest privileged port", "127.0.0.1:1023", "port 1023 is privileged"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAddr(tt.addr)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateAddr(%q) = %v, want nil", tt.addr, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateAddr(%q) = %v, want error containing %q", tt.addr, err, tt.wantErr)
+			}
+			if !errors.Is(err, ErrInvalidAddr) {
+				t.Errorf("ValidateAddr(%q) error %v does not match ErrInvalidAddr", tt.addr, err)
+			}
+		})
+	}
+}
+
+func TestValidateAddrAllowPrivileged(t *testing.T) {
+	if err := validateAddr(":80", true); err != nil {
+		t.Errorf("validateAddr(%q, true) = %v, want nil", ":80", err)
+	}
+	if err := validateAddr("localhost", true); err == nil {
+		t.Errorf("validateAddr(%q, true) = nil, want malformed address error", "localhost")
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// DefaultMaxBatchSize is the default limit on names per /hello/batch request
+const DefaultMaxBatchSize = 100
+
+// maxBatchBytes bounds the size of a /hello/batch request body
+const maxBatchBytes = 1 << 20
+
+// handleBatch greets a JSON array of names, replying with a JSON array of
+// greetings in the same order. It replies 400 Bad Request to a body that is
+// not an array of strings and 413 Request Entity Too Large to one with more
+// than s.MaxBatchSize names.
+func (s *Server) handleBatch(w http.ResponseWriter, r *http.Request) {
+	var names []string
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBatchBytes))
+	err := dec.Decode(&names)
+	if err == nil && dec.Decode(&struct{}{}) != io.EOF {
+		err = errors.New("unexpected data after the array")
+	}
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, fmt.Sprintf("batch body exceeds %d bytes", tooLarge.Limit), http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, fmt.Sprintf("malformed batch, want a JSON array of names: %v", err), http.StatusBadRequest)
+		return
+	}
+	if s.MaxBatchSize > 0 && len(names) > s.MaxBatchSize {
+		http.Error(w, fmt.Sprintf("batch of %d names exceeds the maximum of %d", len(names), s.MaxBatchSize), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	locale := s.requestLocale(r)
+	greetings := make([]Greeting, 0, len(names))
+	for _, name := range names {
+		g, err := s.greet(name, locale)
+		if err != nil {
+			s.greetingFailed(w, name, err)
+			return
+		}
+		greetings = append(greetings, Greeting{Name: name, Greeting: g})
+	}
+	for _, g := range greetings {
+		s.recordGreeting(g.Name, locale)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(greetings)
+}
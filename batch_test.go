@@ -0,0 +1,114 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// postBatch posts body to /hello/batch on srv
+func postBatch(srv *Server, target, body string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, target, strings.NewReader(body)))
+	return rec
+}
+
+func TestHandleBatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		body     string
+		expected []Greeting
+	}{
+		{"names in order", "/hello/batch", `["Alice", "", "Bob"]`, []Greeting{
+			{Name: "Alice", Greeting: "Hello, Alice!"},
+			{Name: "", Greeting: "Hello, World!"},
+			{Name: "Bob", Greeting: "Hello, Bob!"},
+		}},
+		{"empty batch", "/hello/batch", `[]`, []Greeting{}},
+		{"locale", "/hello/batch?locale=es", `["Ana"]`, []Greeting{{Name: "Ana", Greeting: "¡Hola, Ana!"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := postBatch(newTestServer(), tt.target, tt.body)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d (body %q)", rec.Code, http.StatusOK, rec.Body.String())
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Content-Type = %q, want application/json", ct)
+			}
+			var got []Greeting
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("decoding response %q: %v", rec.Body.String(), err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.expected) {
+				t.Errorf("greetings = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHandleBatchRecordsGreetings(t *testing.T) {
+	srv := newTestServer()
+	postBatch(srv, "/hello/batch", `["Alice", "Alice"]`)
+	if n := srv.History.Count("Alice"); n != 2 {
+		t.Errorf("History.Count(Alice) = %d, want 2", n)
+	}
+}
+
+func TestHandleBatchOversized(t *testing.T) {
+	srv := newTestServer()
+	srv.MaxBatchSize = 2
+
+	rec := postBatch(srv, "/hello/batch", `["a", "b", "c"]`)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusRequestEntityTooLarge)
+	}
+	if !strings.Contains(rec.Body.String(), "batch of 3 names exceeds the maximum of 2") {
+		t.Errorf("body = %q, want the batch limit explained", rec.Body.String())
+	}
+
+	rec = postBatch(newTestServer(), "/hello/batch", `["`+strings.Repeat("x", maxBatchBytes)+`"]`)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("oversized body status = %d, want %d", rec.Code, http.StatusRequestEntityTooLarge)
+	}
+}
+
+func TestHandleBatchMalformed(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{"empty body", "", "EOF"},
+		{"truncated", `["Alice"`, "unexpected EOF"},
+		{"object", `{"name": "Alice"}`, "cannot unmarshal object"},
+		{"numbers", `[1, 2]`, "cannot unmarshal number"},
+		{"trailing data", `["Alice"] ["Bob"]`, "unexpected data after the array"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := postBatch(newTestServer(), "/hello/batch", tt.body)
+			if rec.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+			}
+			body := rec.Body.String()
+			if !strings.Contains(body, "malformed batch") || !strings.Contains(body, tt.want) {
+				t.Errorf("body = %q, want a malformed batch error mentioning %q", body, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandleBatchMethodNotAllowed(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newTestServer().Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/hello/batch", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET /hello/batch status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL is how long cached greetings stay fresh by default
+const DefaultCacheTTL = time.Minute
+
+// CacheKey identifies a cached greeting
+type CacheKey struct {
+	Name     string
+	Locale   string
+	Template string
+}
+
+// GreetingCache is a concurrency-safe LRU cache of greetings whose entries
+// expire after a fixed TTL. It holds at most its size entries, evicting the
+// least recently used; a size of zero or less leaves it unbounded and a TTL
+// of zero or less keeps entries until they are evicted.
+type GreetingCache struct {
+	size  int
+	ttl   time.Duration
+	clock Clock
+
+	mu      sync.Mutex
+	entries *lru[CacheKey, cacheEntry]
+}
+
+// cacheEntry is one cached greeting
+type cacheEntry struct {
+	greeting string
+	expires  time.Time
+}
+
+// NewGreetingCache returns an empty cache holding up to size greetings for
+// ttl each
+func NewGreetingCache(size int, ttl time.Duration) *GreetingCache {
+	return &GreetingCache{
+		size:    size,
+		ttl:     ttl,
+		clock:   realClock{},
+		entries: newLRU[CacheKey, cacheEntry](),
+	}
+}
+
+// Get returns the cached greeting for key, if present and not expired
+func (c *GreetingCache) Get(key CacheKey) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries.get(key)
+	if !ok {
+		return "", false
+	}
+	if c.ttl > 0 && !c.clock.Now().Before(entry.expires) {
+		c.entries.remove(key)
+		return "", false
+	}
+	return entry.greeting, true
+}
+
+// Set caches greeting under key, evicting the least recently used entry if
+// the cache is full
+func (c *GreetingCache) Set(key CacheKey, greeting string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries.put(key, cacheEntry{greeting: greeting, expires: c.clock.Now().Add(c.ttl)}, c.size)
+}
+
+// Len returns the number of cached greetings, including expired ones not
+// yet removed
+func (c *GreetingCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries.len()
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGreetingCacheHit(t *testing.T) {
+	c := NewGreetingCache(10, time.Minute)
+	key := CacheKey{Name: "Ana", Locale: "es"}
+
+	if _, ok := c.Get(key); ok {
+		t.Fatal("Get on empty cache hit")
+	}
+	c.Set(key, "¡Hola, Ana!")
+	if g, ok := c.Get(key); !ok || g != "¡Hola, Ana!" {
+		t.Errorf("Get = %q, %v, want %q, true", g, ok, "¡Hola, Ana!")
+	}
+	if _, ok := c.Get(CacheKey{Name: "Ana", Locale: "fr"}); ok {
+		t.Error("Get with a different locale hit")
+	}
+	if _, ok := c.Get(CacheKey{Name: "Ana", Locale: "es", Template: "Hi {{.Name}}"}); ok {
+		t.Error("Get with a different template hit")
+	}
+}
+
+func TestGreetingCacheExpiry(t *testing.T) {
+	clock := newFakeClock(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	c := NewGreetingCache(10, time.Minute)
+	c.clock = clock
+	key := CacheKey{Name: "Bob"}
+
+	c.Set(key, "Hello, Bob!")
+	clock.Advance(59 * time.Second)
+	if _, ok := c.Get(key); !ok {
+		t.Fatal("Get before TTL missed")
+	}
+	clock.Advance(time.Second)
+	if _, ok := c.Get(key); ok {
+		t.Error("Get at TTL hit, want expired")
+	}
+	if n := c.Len(); n != 0 {
+		t.Errorf("Len after expiry = %d, want 0", n)
+	}
+}
+
+func TestGreetingCacheEviction(t *testing.T) {
+	c := NewGreetingCache(2, time.Minute)
+	a, b, d := CacheKey{Name: "A"}, CacheKey{Name: "B"}, CacheKey{Name: "D"}
+
+	c.Set(a, "Hello, A!")
+	c.Set(b, "Hello, B!")
+	c.Get(a)              // A is now most recently used
+	c.Set(d, "Hello, D!") // evicts B
+
+	if n := c.Len(); n != 2 {
+		t.Errorf("Len = %d, want 2", n)
+	}
+	if _, ok := c.Get(b); ok {
+		t.Error("least recently used entry was not evicted")
+	}
+	for _, key := range []CacheKey{a, d} {
+		if _, ok := c.Get(key); !ok {
+			t.Errorf("entry %q was evicted", key.Name)
+		}
+	}
+}
+
+func TestGreetingCacheConcurrent(t *testing.T) {
+	c := NewGreetingCache(8, time.Minute)
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 100 {
+				key := CacheKey{Name: fmt.Sprint(i * j % 10)}
+				c.Set(key, Hello(key.Name))
+				c.Get(key)
+			}
+		}()
+	}
+	wg.Wait()
+	if n := c.Len(); n > 8 {
+		t.Errorf("Len = %d, want at most 8", n)
+	}
+}
+
+func TestServerCache(t *testing.T) {
+	calls := 0
+	srv := newTestServer()
+	srv.Greeter = GreeterFunc(func(name string) string {
+		calls++
+		return Hello(name)
+	})
+	srv.Cache = NewGreetingCache(10, time.Minute)
+	handler := srv.Handler()
+
+	for range 3 {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/hello?name=Alice", nil))
+		if rec.Body.String() != "Hello, Alice!\n" {
+			t.Fatalf("body = %q, want %q", rec.Body.String(), "Hello, Alice!\n")
+		}
+	}
+	if calls != 1 {
+		t.Errorf("greeter called %d times for a repeated name, want 1", calls)
+	}
+}
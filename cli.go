@@ -0,0 +1,492 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Exit codes returned by Run
+const (
+	ExitOK      = 0 // success
+	ExitRuntime = 1 // failure while running, such as an I/O error or invalid name
+	ExitUsage   = 2 // invalid flags or flag values
+)
+
+// ErrInvalidCount is returned for a negative -count
+var ErrInvalidCount = errors.New("invalid count")
+
+// Run runs the command line with args, which exclude the program name, and
+// returns the process exit code, one of ExitOK, ExitRuntime or ExitUsage.
+// Output goes to stdout and stderr; the -stdin and -interactive modes read
+// from os.Stdin.
+func Run(args []string, stdout, stderr io.Writer) int {
+	return run(args, os.Stdin, stdout, stderr)
+}
+
+// options holds the parsed command-line flags
+type options struct {
+	name            string
+	names           string
+	colorMode       string
+	locale          string
+	format          string
+	tmpl            string
+	punct           string
+	timeAware       bool
+	normalize       bool
+	emoji           bool
+	shout           bool
+	reverse         bool
+	random          bool
+	seed            int64
+	count           int
+	wrap            int
+	indent          int
+	maxNameLength   int
+	configPath      string
+	version         bool
+	completion      string
+	readStdin       bool
+	encoding        string
+	csvMode         bool
+	namesFile       string
+	interactive     bool
+	serve           bool
+	healthCheck     bool
+	dump            bool
+	addr            string
+	rps             float64
+	burst           int
+	cacheSize       int
+	maxBatch        int
+	cacheTTL        time.Duration
+	grpcServe       bool
+	grpcAddr        string
+	allowPrivileged bool
+	dryRun          bool
+	logLevel        string
+	otelEndpoint    string
+	shutdownTimeout time.Duration
+}
+
+// newFlagSet returns the command-line flag set, writing errors and usage to
+// output, and the options its flags parse into
+func newFlagSet(output io.Writer) (*flag.FlagSet, *options) {
+	fs := flag.NewFlagSet(binaryName, flag.ContinueOnError)
+	fs.SetOutput(output)
+	fs.Usage = func() { usage(fs) }
+
+	o := &options{}
+	fs.StringVar(&o.name, "name", "", "name to greet (defaults to World)")
+	fs.StringVar(&o.names, "names", "", "comma-separated `list` of names to greet, with double quotes around names containing commas")
+	fs.StringVar(&o.colorMode, "color", colorAuto, "colorize text output: auto, always or never")
+	fs.StringVar(&o.locale, "locale", "", "greeting locale, one of "+strings.Join(SupportedLocales(), ", "))
+	fs.StringVar(&o.format, "format", formatText, "output format: text or json")
+	fs.StringVar(&o.tmpl, "template", "", "greeting `template` using {{.Name}}, e.g. \"Hey, {{.Name}}!\"")
+	fs.StringVar(&o.punct, "punct", Exclaim.String(), "greeting punctuation: exclaim, period or none")
+	fs.BoolVar(&o.timeAware, "time-aware", false, "greet according to the current time of day")
+	fs.BoolVar(&o.normalize, "normalize", false, "trim and title-case names before greeting")
+	fs.BoolVar(&o.emoji, "emoji", false, "append a waving hand emoji to the greeting")
+	fs.BoolVar(&o.shout, "shout", false, "print the greeting in upper case")
+	fs.BoolVar(&o.reverse, "reverse", false, "print the greeting reversed")
+	fs.BoolVar(&o.random, "random", false, "greet with a randomly chosen salutation")
+	fs.Int64Var(&o.seed, "seed", 0, "random seed for -random (defaults to the current time)")
+	fs.IntVar(&o.count, "count", 1, "print each greeting `n` times")
+	fs.IntVar(&o.wrap, "wrap", 0, "wrap text greetings at `width` characters (0 disables)")
+	fs.IntVar(&o.indent, "indent", 0, "indent each line of text greetings by `n` spaces")
+	fs.IntVar(&o.maxNameLength, "max-name-length", DefaultMaxNameLength, "maximum name length in characters")
+	fs.StringVar(&o.configPath, "config", "", "load defaults from a JSON or YAML config `file`, reloaded on SIGHUP with -serve")
+	fs.BoolVar(&o.version, "version", false, "print version information and exit")
+	fs.StringVar(&o.completion, "completion", "", "print a completion script for `shell` (bash, zsh or fish) and exit")
+	fs.BoolVar(&o.readStdin, "stdin", false, "read names from standard input, one per line")
+	fs.StringVar(&o.encoding, "encoding", "", "character `encoding` of -stdin, -csv and -file input: utf-8, latin1 or shift-jis")
+	fs.BoolVar(&o.csvMode, "csv", false, "read a CSV of names from standard input and write name,greeting CSV")
+	fs.StringVar(&o.namesFile, "file", "", "greet each line of `file`, reporting invalid lines")
+	fs.BoolVar(&o.interactive, "interactive", false, "greet names typed at a prompt until exit or quit")
+	fs.BoolVar(&o.serve, "serve", false, "serve greetings over HTTP")
+	fs.StringVar(&o.addr, "addr", ":8080", "listen address for -serve")
+	fs.BoolVar(&o.healthCheck, "healthcheck", false, "check that the -serve server on -addr greets correctly and exit; the greeting must match exactly, so pass the server's greeting flags such as -locale or -shout")
+	fs.BoolVar(&o.dump, "dump", false, "print a JSON snapshot of the -serve server on -addr and exit")
+	fs.Float64Var(&o.rps, "rate", 0, "per-IP request rate limit for -serve in requests per second (0 disables)")
+	fs.IntVar(&o.burst, "burst", 10, "per-IP request burst for -rate")
+	fs.IntVar(&o.maxBatch, "max-batch", DefaultMaxBatchSize, "maximum names per -serve /hello/batch request")
+	fs.IntVar(&o.cacheSize, "cache-size", 0, "number of greetings -serve caches (0 disables)")
+	fs.DurationVar(&o.cacheTTL, "cache-ttl", DefaultCacheTTL, "how long -serve caches each greeting")
+	fs.BoolVar(&o.grpcServe, "grpc", false, "serve greetings over gRPC")
+	fs.StringVar(&o.grpcAddr, "grpc-addr", ":9090", "listen address for -grpc")
+	fs.BoolVar(&o.allowPrivileged, "allow-privileged-port", false, "allow listening on ports below 1024")
+	fs.BoolVar(&o.dryRun, "dry-run", false, "with -serve or -grpc, validate the configuration and exit without listening")
+	fs.StringVar(&o.logLevel, "log-level", "info", "log level: debug, info, warn or error")
+	fs.StringVar(&o.otelEndpoint, "otel-endpoint", "", "export -serve traces over OTLP/HTTP to `url`, e.g. http://localhost:4318")
+	fs.DurationVar(&o.shutdownTimeout, "shutdown-timeout", DefaultShutdownTimeout, "graceful shutdown timeout for -serve and -grpc")
+	return fs, o
+}
+
+// run is Run with standard input injected
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs, opts := newFlagSet(stderr)
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return ExitOK
+		}
+		return ExitUsage
+	}
+
+	// fail reports err and returns code; usageError also prints usage.
+	fail := func(code int, err error) int {
+		fmt.Fprintln(stderr, err)
+		return code
+	}
+	usageError := func(err error) int {
+		fmt.Fprintln(stderr, err)
+		fs.Usage()
+		return ExitUsage
+	}
+
+	if opts.version {
+		fmt.Fprintln(stdout, VersionString())
+		return ExitOK
+	}
+
+	if opts.completion != "" {
+		if err := GenerateCompletion(opts.completion, stdout); err != nil {
+			return usageError(err)
+		}
+		return ExitOK
+	}
+
+	logger, err := SetupLogger(opts.logLevel, stderr)
+	if err != nil {
+		return usageError(err)
+	}
+
+	// Environment defaults are applied first so that, like flags, they
+	// take precedence over the config file.
+	if err := applyConfig(fs, ConfigFromEnv()); err != nil {
+		return fail(ExitRuntime, err)
+	}
+	overrides := explicitConfig(fs)
+	if opts.configPath != "" {
+		cfg, err := LoadConfig(opts.configPath)
+		if err == nil {
+			err = applyConfig(fs, cfg)
+		}
+		if err != nil {
+			return fail(ExitRuntime, err)
+		}
+	}
+
+	if err := validateFormat(opts.format); err != nil {
+		return usageError(err)
+	}
+	if opts.count < 0 {
+		return usageError(fmt.Errorf("%w %d: must not be negative", ErrInvalidCount, opts.count))
+	}
+	if opts.wrap < 0 || opts.indent < 0 {
+		return usageError(fmt.Errorf("invalid -wrap %d or -indent %d: must not be negative", opts.wrap, opts.indent))
+	}
+	if opts.encoding != "" {
+		if !opts.readStdin && !opts.csvMode && opts.namesFile == "" {
+			return usageError(errors.New("-encoding only applies to -stdin, -csv and -file input"))
+		}
+		dr, err := newDecodingReader(stdin, opts.encoding)
+		if err != nil {
+			return usageError(err)
+		}
+		stdin = dr
+	}
+	// The cache would pin the first random or time-of-day greeting.
+	if opts.cacheSize > 0 && (opts.random || opts.timeAware) {
+		return usageError(errors.New("-cache-size cannot be combined with -random or -time-aware"))
+	}
+
+	color, err := colorEnabled(opts.colorMode, stdout)
+	if err != nil {
+		return usageError(err)
+	}
+
+	style, err := ParsePunctuationStyle(opts.punct)
+	if err != nil {
+		return usageError(err)
+	}
+	// Each of these replaces the default greeting, which is the one -punct
+	// styles, so only one can apply and only with the default punctuation.
+	var replacing []string
+	for _, f := range []struct {
+		name string
+		set  bool
+	}{{"-template", opts.tmpl != ""}, {"-random", opts.random}, {"-time-aware", opts.timeAware}} {
+		if f.set {
+			replacing = append(replacing, f.name)
+		}
+	}
+	if len(replacing) > 1 {
+		return usageError(fmt.Errorf("%s cannot be combined", strings.Join(replacing, " and ")))
+	}
+	if len(replacing) == 1 && style != Exclaim {
+		return usageError(fmt.Errorf("-punct %s cannot be combined with %s", style, replacing[0]))
+	}
+	var base Greeter = DefaultGreeter{}
+	if style != Exclaim {
+		base = StyledGreeter{Style: style}
+	}
+	if opts.random {
+		seeded := false
+		fs.Visit(func(f *flag.Flag) { seeded = seeded || f.Name == "seed" })
+		if !seeded {
+			opts.seed = time.Now().UnixNano()
+		}
+		// rand.Rand is not safe for concurrent use, and -serve greets from
+		// many goroutines
+		var mu sync.Mutex
+		rng := rand.New(rand.NewSource(opts.seed))
+		base = GreeterFunc(func(name string) string {
+			mu.Lock()
+			defer mu.Unlock()
+			return HelloRandom(name, rng)
+		})
+	}
+	if opts.timeAware {
+		base = TimeOfDayGreeter{Clock: realClock{}}
+	}
+	if opts.tmpl != "" {
+		t, err := parseGreetingTemplate(opts.tmpl)
+		if err != nil {
+			return fail(ExitUsage, err)
+		}
+		// parseGreetingTemplate makes render failures unlikely but cannot
+		// rule them out, so they are reported through TryGreet
+		base = templateGreeter{t: t}
+	}
+	var transforms []Transform
+	if opts.emoji {
+		transforms = append(transforms, EmojiTransform)
+	}
+	if opts.shout {
+		transforms = append(transforms, ShoutTransform)
+	}
+	if opts.reverse {
+		transforms = append(transforms, ReverseTransform)
+	}
+	composite := CompositeGreeter{Base: base, Transforms: transforms}
+	greeter := withLocale(composite, opts.locale)
+
+	if opts.healthCheck {
+		url, err := healthCheckURL(opts.addr)
+		if err != nil {
+			return fail(ExitUsage, err)
+		}
+		// A -random server's greeting cannot be predicted.
+		want := ""
+		if !opts.random {
+			want = greeter.Greet(healthCheckName)
+		}
+		if err := checkHealth(url, want, DefaultHealthCheckTimeout); err != nil {
+			return fail(ExitRuntime, err)
+		}
+		return ExitOK
+	}
+
+	if opts.dump {
+		url, err := healthCheckURL(opts.addr)
+		if err != nil {
+			return fail(ExitUsage, err)
+		}
+		if err := DumpSnapshot(url, DefaultHealthCheckTimeout, stdout); err != nil {
+			return fail(ExitRuntime, err)
+		}
+		return ExitOK
+	}
+
+	if opts.serve || opts.grpcServe {
+		var addrs []string
+		if opts.serve {
+			addrs = append(addrs, opts.addr)
+		}
+		if opts.grpcServe {
+			addrs = append(addrs, opts.grpcAddr)
+		}
+		for _, a := range addrs {
+			if err := validateAddr(a, opts.allowPrivileged); err != nil {
+				return fail(ExitUsage, err)
+			}
+		}
+		// Check everything else the servers would only reject once
+		// starting, so that -dry-run covers it too
+		if opts.otelEndpoint != "" {
+			if err := validateEndpoint(opts.otelEndpoint); err != nil {
+				return fail(ExitUsage, err)
+			}
+		}
+		if opts.rps < 0 || opts.burst < 0 || opts.cacheSize < 0 || opts.shutdownTimeout < 0 {
+			return usageError(fmt.Errorf("invalid -rate %g, -burst %d, -cache-size %d or -shutdown-timeout %s: must not be negative",
+				opts.rps, opts.burst, opts.cacheSize, opts.shutdownTimeout))
+		}
+		if opts.dryRun {
+			if opts.serve {
+				fmt.Fprintf(stdout, "would serve HTTP greetings on %s\n", opts.addr)
+			}
+			if opts.grpcServe {
+				fmt.Fprintf(stdout, "would serve gRPC greetings on %s\n", opts.grpcAddr)
+			}
+			if opts.otelEndpoint != "" {
+				fmt.Fprintf(stdout, "would export traces to %s\n", opts.otelEndpoint)
+			}
+			fmt.Fprintf(stdout, "shutdown timeout %s, log level %s\n", opts.shutdownTimeout, opts.logLevel)
+			return ExitOK
+		}
+
+		shutdownTracing, err := SetupTracing(context.Background(), opts.otelEndpoint)
+		if err != nil {
+			return fail(ExitUsage, err)
+		}
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), opts.shutdownTimeout)
+			defer cancel()
+			if err := shutdownTracing(ctx); err != nil {
+				logger.Error("flushing traces", "err", err)
+			}
+		}()
+
+		var serves []func(context.Context) error
+		if opts.serve {
+			srv := NewServer(opts.addr)
+			srv.ShutdownTimeout = opts.shutdownTimeout
+			srv.Logger = logger
+			srv.Greeter = composite
+			if opts.timeAware {
+				// Tell the time on the server's clock, like /hello/time
+				srv.Greeter = CompositeGreeter{Base: TimeOfDayGreeter{Clock: srv.Clock}, Transforms: transforms}
+			}
+			srv.SetConfig(&Config{DefaultName: opts.name, Locale: opts.locale, Format: opts.format, Template: opts.tmpl})
+			srv.ConfigPath = opts.configPath
+			srv.ConfigOverrides = overrides
+			srv.MaxBatchSize = opts.maxBatch
+			if opts.rps > 0 {
+				srv.RateLimiter = NewRateLimiter(opts.rps, opts.burst)
+			}
+			if opts.cacheSize > 0 {
+				srv.Cache = NewGreetingCache(opts.cacheSize, opts.cacheTTL)
+			}
+			serves = append(serves, srv.ListenAndServe)
+		}
+		if opts.grpcServe {
+			srv := NewGRPCServer(opts.grpcAddr)
+			srv.ShutdownTimeout = opts.shutdownTimeout
+			srv.Logger = logger
+			srv.Greeter = greeter
+			serves = append(serves, srv.ListenAndServe)
+		}
+		if err := serveUntilSignal(serves...); err != nil {
+			logger.Error("server failed", "err", err)
+			return ExitRuntime
+		}
+		return ExitOK
+	}
+
+	if opts.namesFile != "" {
+		greetings, err := helloFile(opts.namesFile, opts.encoding, opts.maxNameLength)
+		for _, g := range greetings {
+			fmt.Fprintln(stdout, g)
+		}
+		if err != nil {
+			return fail(ExitRuntime, err)
+		}
+		return ExitOK
+	}
+
+	if opts.interactive {
+		if err := RunREPL(stdin, stdout); err != nil {
+			return fail(ExitRuntime, err)
+		}
+		return ExitOK
+	}
+
+	if opts.csvMode {
+		if err := HelloCSV(stdin, stdout); err != nil {
+			return fail(ExitRuntime, err)
+		}
+		return ExitOK
+	}
+
+	if opts.readStdin {
+		// HelloStreamContext counts the lines that reached out, so they must
+		// not stop in a buffer on the way
+		out := stdout
+		if b, ok := stdout.(interface{ Unbuffered() (io.Writer, error) }); ok {
+			if out, err = b.Unbuffered(); err != nil {
+				return fail(ExitRuntime, err)
+			}
+		}
+		ctx, stop := interruptContext()
+		defer stop()
+		if err := HelloStreamContext(ctx, stdin, out); err != nil {
+			return fail(ExitRuntime, err)
+		}
+		return ExitOK
+	}
+
+	names, list := []string{opts.name}, opts.count != 1
+	if fs.NArg() > 0 {
+		names, list = fs.Args(), true
+	}
+	if opts.names != "" {
+		parsed, err := ParseNames(opts.names)
+		if err != nil {
+			return usageError(err)
+		}
+		names, list = append(parsed, fs.Args()...), true
+	}
+	greetings := make([]Greeting, 0, len(names)*opts.count)
+	for _, n := range names {
+		if opts.normalize {
+			n = NormalizeName(n)
+		}
+		if err := validateName(n, opts.maxNameLength); err != nil {
+			return fail(ExitRuntime, fmt.Errorf("invalid name %q: %w", n, err))
+		}
+		g, err := TryGreet(greeter, n)
+		if err != nil {
+			return fail(ExitRuntime, err)
+		}
+		if opts.format == formatText {
+			g = indentLines(WrapGreeting(g, opts.wrap), opts.indent)
+			g = ColorizeGreeting(g, color)
+		}
+		for range opts.count {
+			greetings = append(greetings, Greeting{Name: n, Greeting: g})
+		}
+	}
+	if err := writeGreetings(stdout, opts.format, greetings, list); err != nil {
+		return fail(ExitRuntime, err)
+	}
+	return ExitOK
+}
+
+// usage prints the command synopsis and flag defaults to the output of fs
+func usage(fs *flag.FlagSet) {
+	out := fs.Output()
+	fmt.Fprintf(out, "Codex Universal - Multi-language development environment\n\n")
+	fmt.Fprintf(out, "Usage: %s [flags] [name ...]\n\nFlags:\n", binaryName)
+	fs.PrintDefaults()
+	fmt.Fprintf(out, "\nEnvironment:\n  %s, %s, %s\n    \tdefaults for -name, -locale and -format, overridden by flags\n", envName, envLocale, envFormat)
+}
+
+// interruptContext returns a context canceled by the first SIGINT or
+// SIGTERM. Default signal handling is then restored, so a second signal
+// kills a process blocked reading input.
+func interruptContext() (context.Context, context.CancelFunc) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	context.AfterFunc(ctx, stop)
+	return ctx, stop
+}
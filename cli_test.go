@@ -0,0 +1,282 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+// runCLI runs the command line with args and input on standard input and
+// returns its output and exit code
+func runCLI(t *testing.T, input string, args ...string) (stdout, stderr string, code int) {
+	t.Helper()
+	var outBuf, errBuf bytes.Buffer
+	code = run(args, strings.NewReader(input), &outBuf, &errBuf)
+	return outBuf.String(), errBuf.String(), code
+}
+
+func TestRunConfig(t *testing.T) {
+	path := writeConfig(t, "config.yaml", "default_name: Alice\nlocale: es\n")
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantStdout string
+	}{
+		{"config defaults", []string{"-config", path}, "¡Hola, Alice!\n"},
+		{"flags override config", []string{"-config", path, "-name", "Bob", "-locale", "de"}, "Hallo, Bob!\n"},
+		{"flag order does not matter", []string{"-name", "Bob", "-config", path}, "¡Hola, Bob!\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout, stderr, code := runCLI(t, "", tt.args...)
+			if code != ExitOK {
+				t.Fatalf("exit code = %d, want %d (stderr: %q)", code, ExitOK, stderr)
+			}
+			if stdout != tt.wantStdout {
+				t.Errorf("stdout = %q, want %q", stdout, tt.wantStdout)
+			}
+		})
+	}
+}
+
+func TestRunEnv(t *testing.T) {
+	path := writeConfig(t, "config.yaml", "default_name: Alice\nlocale: es\n")
+
+	tests := []struct {
+		name       string
+		env        map[string]string
+		args       []string
+		wantStdout string
+	}{
+		{"env defaults", map[string]string{"CODEX_NAME": "Eve", "CODEX_LOCALE": "fr"}, nil, "Bonjour, Eve!\n"},
+		{"env format", map[string]string{"CODEX_NAME": "Eve", "CODEX_FORMAT": "json"}, nil, `{"name":"Eve","greeting":"Hello, Eve!"}` + "\n"},
+		{"flags override env", map[string]string{"CODEX_NAME": "Eve", "CODEX_LOCALE": "fr"}, []string{"-name", "Bob", "-locale", "de"}, "Hallo, Bob!\n"},
+		{"env overrides config", map[string]string{"CODEX_NAME": "Eve"}, []string{"-config", path}, "¡Hola, Eve!\n"},
+		{"empty env is unset", map[string]string{"CODEX_NAME": "", "CODEX_LOCALE": ""}, []string{"-config", path}, "¡Hola, Alice!\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"CODEX_NAME", "CODEX_LOCALE", "CODEX_FORMAT"} {
+				t.Setenv(key, tt.env[key])
+			}
+			stdout, stderr, code := runCLI(t, "", tt.args...)
+			if code != ExitOK {
+				t.Fatalf("exit code = %d, want %d (stderr: %q)", code, ExitOK, stderr)
+			}
+			if stdout != tt.wantStdout {
+				t.Errorf("stdout = %q, want %q", stdout, tt.wantStdout)
+			}
+		})
+	}
+}
+
+func TestRunFile(t *testing.T) {
+	stdout, stderr, code := runCLI(t, "", "-file", writeNames(t, "Alice\nBo\tb\n\n"))
+	if code != ExitRuntime {
+		t.Errorf("exit code = %d, want %d", code, ExitRuntime)
+	}
+	if stdout != "Hello, Alice!\nHello, World!\n" {
+		t.Errorf("stdout = %q, want greetings for the valid lines", stdout)
+	}
+	if !strings.Contains(stderr, ":2: name contains control character") {
+		t.Errorf("stderr = %q, want the invalid line reported", stderr)
+	}
+}
+
+func TestRunMaxNameLength(t *testing.T) {
+	stdout, stderr, code := runCLI(t, "", "-max-name-length", "3", "-file", writeNames(t, "Bob\nAlice\n"))
+	if code != ExitRuntime || stdout != "Hello, Bob!\n" || !strings.Contains(stderr, ":2: name is 5 characters long, maximum is 3") {
+		t.Errorf("-file with -max-name-length 3 = %q, %q, %d, want only Bob greeted", stdout, stderr, code)
+	}
+	if MaxNameLength != DefaultMaxNameLength {
+		t.Errorf("MaxNameLength = %d after run, want it left at %d", MaxNameLength, DefaultMaxNameLength)
+	}
+}
+
+func TestRunFileEncoding(t *testing.T) {
+	stdout, stderr, code := runCLI(t, "", "-encoding", "latin1", "-file", writeNames(t, "Jos\xe9\nFran\xe7ois\n"))
+	if code != ExitOK || stdout != "Hello, José!\nHello, François!\n" {
+		t.Errorf("-file latin1 = %q, %d (stderr %q), want both names greeted", stdout, code, stderr)
+	}
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantCode   int
+		wantStdout string
+		wantStderr string
+	}{
+		{"no flags", nil, ExitOK, "Hello, World!\n", ""},
+		{"empty name", []string{"-name", ""}, ExitOK, "Hello, World!\n", ""},
+		{"with name", []string{"-name", "Gopher"}, ExitOK, "Hello, Gopher!\n", ""},
+		{"positional names", []string{"Alice", "Bob", ""}, ExitOK, "Hello, Alice!\nHello, Bob!\nHello, World!\n", ""},
+		{"json name", []string{"-format", "json", "-name", "Gopher"}, ExitOK, `{"name":"Gopher","greeting":"Hello, Gopher!"}` + "\n", ""},
+		{"json positional names", []string{"-format", "json", "Alice", ""}, ExitOK, `[{"name":"Alice","greeting":"Hello, Alice!"},{"name":"","greeting":"Hello, World!"}]` + "\n", ""},
+		{"unknown format", []string{"-format", "xml"}, ExitUsage, "", `unknown format "xml"`},
+		{"template", []string{"-template", "Hey, {{.Name}}!", "-name", "Alice"}, ExitOK, "Hey, Alice!\n", ""},
+		{"invalid template", []string{"-template", "{{.Name"}, ExitUsage, "", "invalid greeting template"},
+		{"template render error", []string{"-template", "{{if gt (len .Name) 10}}{{index .Name 100}}{{end}}Hi", "Bartholomew"}, ExitRuntime, "", "render greeting template"},
+		{"locale", []string{"-locale", "es", "-name", "Ana"}, ExitOK, "¡Hola, Ana!\n", ""},
+		{"missing config", []string{"-config", "does-not-exist.json"}, ExitRuntime, "", "no such file"},
+		{"color always", []string{"-color", "always", "-name", "Ana"}, ExitOK, "\x1b[1;32mHello, Ana!\x1b[0m\n", ""},
+		{"color never", []string{"-color", "never", "-template", "\x1b[31m{{.Name}}"}, ExitOK, "World\n", ""},
+		{"unknown color", []string{"-color", "sometimes"}, ExitUsage, "", "unknown color mode"},
+		{"shout", []string{"-shout", "-name", "Alice"}, ExitOK, "HELLO, ALICE!\n", ""},
+		{"shout and reverse", []string{"-shout", "-reverse", "-locale", "es", "-name", "José"}, ExitOK, "!ÉSOJ ,ALOH¡\n", ""},
+		{"invalid name", []string{"-name", "Ali\nce"}, ExitRuntime, "", "invalid name \"Ali\\nce\": name contains control character"},
+		{"name too long", []string{"-max-name-length", "3", "Alice"}, ExitRuntime, "", "maximum is 3"},
+		{"emoji", []string{"-emoji", "-name", "Alice"}, ExitOK, "Hello, Alice! 👋\n", ""},
+		{"emoji json", []string{"-emoji", "-format", "json", "-name", "Alice"}, ExitOK, `{"name":"Alice","greeting":"Hello, Alice! 👋"}` + "\n", ""},
+		{"dry run", []string{"-serve", "-addr", "127.0.0.1:8081", "-dry-run"}, ExitOK, "would serve HTTP greetings on 127.0.0.1:8081\nshutdown timeout 5s, log level info\n", ""},
+		{"dry run grpc", []string{"-grpc", "-grpc-addr", ":9091", "-dry-run"}, ExitOK, "would serve gRPC greetings on :9091\nshutdown timeout 5s, log level info\n", ""},
+		{"dry run malformed addr", []string{"-serve", "-addr", "localhost", "-dry-run"}, ExitUsage, "", "missing port"},
+		{"dry run privileged port", []string{"-serve", "-addr", ":80", "-dry-run"}, ExitUsage, "", "port 80 is privileged"},
+		{"dry run bad otel endpoint", []string{"-serve", "-dry-run", "-otel-endpoint", "::bad"}, ExitUsage, "", "invalid OTLP endpoint"},
+		{"dry run otel endpoint", []string{"-serve", "-addr", ":8081", "-dry-run", "-otel-endpoint", "http://localhost:4318"}, ExitOK, "would serve HTTP greetings on :8081\nwould export traces to http://localhost:4318\nshutdown timeout 5s, log level info\n", ""},
+		{"dry run negative rate", []string{"-serve", "-dry-run", "-rate", "-1"}, ExitUsage, "", "must not be negative"},
+		{"dry run negative shutdown timeout", []string{"-grpc", "-dry-run", "-shutdown-timeout", "-1s"}, ExitUsage, "", "must not be negative"},
+		{"dry run privileged port allowed", []string{"-serve", "-addr", ":80", "-allow-privileged-port", "-dry-run"}, ExitOK, "would serve HTTP greetings on :80\nshutdown timeout 5s, log level info\n", ""},
+		{"normalize", []string{"-normalize", "  josé ", "ALICE"}, ExitOK, "Hello, José!\nHello, Alice!\n", ""},
+		{"count zero", []string{"-count", "0", "-name", "Alice"}, ExitOK, "", ""},
+		{"count one", []string{"-count", "1", "-name", "Alice"}, ExitOK, "Hello, Alice!\n", ""},
+		{"count several", []string{"-count", "3", "Alice", "Bob"}, ExitOK, "Hello, Alice!\nHello, Alice!\nHello, Alice!\nHello, Bob!\nHello, Bob!\nHello, Bob!\n", ""},
+		{"count json", []string{"-count", "2", "-format", "json", "-name", "Alice"}, ExitOK, `[{"name":"Alice","greeting":"Hello, Alice!"},{"name":"Alice","greeting":"Hello, Alice!"}]` + "\n", ""},
+		{"count negative", []string{"-count", "-1"}, ExitUsage, "", "invalid count -1"},
+		{"unknown log level", []string{"-log-level", "loud"}, ExitUsage, "", `unknown log level "loud"`},
+		{"missing names file", []string{"-file", "does-not-exist.txt"}, ExitRuntime, "", "no such file"},
+		{"random seeded", []string{"-random", "-seed", "42", "Alice", "Bob", "Carol"}, ExitOK, "Hello, Alice!\nGreetings, Bob!\nHi, Carol!\n", ""},
+		{"completion unsupported", []string{"-completion", "tcsh"}, ExitUsage, "", `unsupported completion shell "tcsh"`},
+		{"wrap", []string{"-wrap", "8", "Alice", "Bartholomew"}, ExitOK, "Hello,\nAlice!\nHello,\nBartholo\nmew!\n", ""},
+		{"wrap and indent", []string{"-wrap", "6", "-indent", "2", "-locale", "es", "-name", "José"}, ExitOK, "  ¡Hola,\n  José!\n", ""},
+		{"indent", []string{"-indent", "4", "-name", "Alice"}, ExitOK, "    Hello, Alice!\n", ""},
+		{"negative wrap", []string{"-wrap", "-1"}, ExitUsage, "", "invalid -wrap -1"},
+		{"encoding without input", []string{"-encoding", "latin1", "Alice"}, ExitUsage, "", "-encoding only applies to -stdin, -csv and -file"},
+		{"unknown encoding", []string{"-stdin", "-encoding", "bogus"}, ExitUsage, "", `unknown encoding "bogus"`},
+		{"random with time-aware", []string{"-random", "-time-aware", "Alice"}, ExitUsage, "", "-random and -time-aware cannot be combined"},
+		{"template with random", []string{"-template", "Hey, {{.Name}}", "-random", "Alice"}, ExitUsage, "", "-template and -random cannot be combined"},
+		{"punct with time-aware", []string{"-time-aware", "-punct", "none", "Alice"}, ExitUsage, "", "-punct none cannot be combined with -time-aware"},
+		{"punct with template", []string{"-template", "Hey, {{.Name}}", "-punct", "period", "Alice"}, ExitUsage, "", "-punct period cannot be combined with -template"},
+		{"punct exclaim with random", []string{"-random", "-seed", "42", "-punct", "exclaim", "Alice"}, ExitOK, "Hello, Alice!\n", ""},
+		{"cache with random", []string{"-serve", "-random", "-cache-size", "100"}, ExitUsage, "", "-cache-size cannot be combined"},
+		{"cache with time-aware", []string{"-serve", "-time-aware", "-cache-size", "100"}, ExitUsage, "", "-cache-size cannot be combined"},
+		{"punct period", []string{"-punct", "period", "Alice", ""}, ExitOK, "Hello, Alice.\nHello, World.\n", ""},
+		{"punct none spanish", []string{"-punct", "none", "-locale", "es", "-name", "Ana"}, ExitOK, "Hola, Ana\n", ""},
+		{"punct exclaim spanish", []string{"-punct", "exclaim", "-locale", "es", "-name", "Ana"}, ExitOK, "¡Hola, Ana!\n", ""},
+		{"unknown punct", []string{"-punct", "question"}, ExitUsage, "", `unknown punctuation style "question"`},
+		{"names", []string{"-names", `"Smith, John", Alice`}, ExitOK, "Hello, Smith, John!\nHello, Alice!\n", ""},
+		{"names and positional", []string{"-names", "Alice,Bob", "Carol"}, ExitOK, "Hello, Alice!\nHello, Bob!\nHello, Carol!\n", ""},
+		{"names unterminated quote", []string{"-names", `"Smith, John`}, ExitUsage, "", "unterminated quote"},
+		{"version", []string{"-version"}, ExitOK, VersionString() + "\n", ""},
+		{"help", []string{"-h"}, ExitOK, "", "Usage: " + binaryName},
+		{"unknown flag", []string{"-bogus"}, ExitUsage, "", "Usage: " + binaryName},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout, stderr, code := runCLI(t, "", tt.args...)
+			if code != tt.wantCode {
+				t.Errorf("exit code = %d, want %d (stderr: %q)", code, tt.wantCode, stderr)
+			}
+			if stdout != tt.wantStdout {
+				t.Errorf("stdout = %q, want %q", stdout, tt.wantStdout)
+			}
+			if !strings.Contains(stderr, tt.wantStderr) {
+				t.Errorf("stderr = %q, want it to contain %q", stderr, tt.wantStderr)
+			}
+		})
+	}
+}
+
+func TestRunInput(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		input      string
+		wantStdout string
+	}{
+		{"stdin", []string{"-stdin"}, "Alice\n\nBob\n", "Hello, Alice!\nHello, World!\nHello, Bob!\n"},
+		{"stdin latin1", []string{"-stdin", "-encoding", "latin1"}, "Jos\xe9\n", "Hello, José!\n"},
+		{"csv", []string{"-csv"}, "name\n\"Smith, John\"\n", "name,greeting\n\"Smith, John\",\"Hello, Smith, John!\"\n"},
+		{"csv latin1", []string{"-csv", "-encoding", "latin1"}, "name\nZo\xeb\n", "name,greeting\nZoë,\"Hello, Zoë!\"\n"},
+		{"interactive", []string{"-interactive"}, "Alice\nquit\n", "> Hello, Alice!\n> "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stdout, stderr, code := runCLI(t, tt.input, tt.args...)
+			if code != ExitOK {
+				t.Fatalf("exit code = %d, want %d (stderr: %q)", code, ExitOK, stderr)
+			}
+			if stdout != tt.wantStdout {
+				t.Errorf("stdout = %q, want %q", stdout, tt.wantStdout)
+			}
+		})
+	}
+}
+
+func TestRunExported(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{"-name", "Gopher"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("Run exit code = %d, want 0 (stderr: %q)", code, stderr.String())
+	}
+	if stdout.String() != "Hello, Gopher!\n" {
+		t.Errorf("Run stdout = %q, want %q", stdout.String(), "Hello, Gopher!\n")
+	}
+}
+
+// failingWriter fails every write
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("write failed") }
+
+func TestRunWriteError(t *testing.T) {
+	var stderr bytes.Buffer
+	if code := run([]string{"-name", "Gopher"}, strings.NewReader(""), failingWriter{}, &stderr); code != ExitRuntime {
+		t.Errorf("exit code = %d, want %d", code, ExitRuntime)
+	}
+	if !strings.Contains(stderr.String(), "write failed") {
+		t.Errorf("stderr = %q, want the write error reported", stderr.String())
+	}
+}
+
+func TestRunStdinSecondInterruptKills(t *testing.T) {
+	if os.Getenv("CODEX_TEST_STDIN_CHILD") == "1" {
+		os.Exit(Run([]string{"-stdin"}, os.Stdout, os.Stderr))
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestRunStdinSecondInterruptKills$")
+	cmd.Env = append(os.Environ(), "CODEX_TEST_STDIN_CHILD=1")
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		t.Fatalf("StdinPipe: %v", err)
+	}
+	defer stdin.Close()
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	// The child blocks reading the open pipe, so only the second signal,
+	// once default handling is restored, can end it.
+	time.Sleep(200 * time.Millisecond)
+	for range 2 {
+		cmd.Process.Signal(os.Interrupt)
+		time.Sleep(100 * time.Millisecond)
+	}
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		cmd.Process.Kill()
+		t.Fatal("-stdin still running after a second SIGINT")
+	}
+}
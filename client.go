@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Client defaults
+const (
+	DefaultMaxAttempts = 3
+	DefaultBackoff     = 100 * time.Millisecond
+	DefaultMaxBackoff  = 2 * time.Second
+)
+
+// Client calls the greeting server's /hello endpoint, retrying failed
+// requests with exponential backoff
+type Client struct {
+	BaseURL    string
+	HTTPClient *http.Client
+	// MaxAttempts is the total number of requests made per call, including
+	// the first
+	MaxAttempts int
+	// Backoff is the delay before the first retry; it doubles after each
+	// further failure up to MaxBackoff
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// NewClient returns a Client for the server at baseURL with default settings
+func NewClient(baseURL string) *Client {
+	return &Client{
+		BaseURL:     baseURL,
+		HTTPClient:  http.DefaultClient,
+		MaxAttempts: DefaultMaxAttempts,
+		Backoff:     DefaultBackoff,
+		MaxBackoff:  DefaultMaxBackoff,
+	}
+}
+
+// retryableError marks a failure worth retrying, such as a network error or
+// a 5xx response
+type retryableError struct {
+	err error
+}
+
+func (e *retryableError) Error() string { return e.err.Error() }
+func (e *retryableError) Unwrap() error { return e.err }
+
+// Greet returns the server's greeting for name. Network errors and 5xx
+// responses are retried up to c.MaxAttempts times; waiting between attempts
+// stops early when ctx is done. The trace context of ctx is propagated in
+// the request headers.
+func (c *Client) Greet(ctx context.Context, name string) (string, error) {
+	u, err := url.Parse(c.BaseURL)
+	if err != nil {
+		return "", fmt.Errorf("greet: invalid base URL: %w", err)
+	}
+	u = u.JoinPath("hello")
+	u.RawQuery = url.Values{"name": {name}}.Encode()
+
+	delay := c.Backoff
+	for attempt := 1; ; attempt++ {
+		greeting, err := c.greetOnce(ctx, u.String())
+		if err == nil {
+			return greeting, nil
+		}
+		var retryable *retryableError
+		if !errors.As(err, &retryable) || attempt >= c.MaxAttempts {
+			return "", fmt.Errorf("greet %q: attempt %d: %w", name, attempt, err)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return "", fmt.Errorf("greet %q: %w", name, ctx.Err())
+		case <-timer.C:
+		}
+		if delay *= 2; c.MaxBackoff > 0 && delay > c.MaxBackoff {
+			delay = c.MaxBackoff
+		}
+	}
+}
+
+// greetOnce makes a single /hello request to target
+func (c *Client) greetOnce(ctx context.Context, target string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "text/plain")
+	injectTraceContext(ctx, req)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		return "", &retryableError{err}
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	switch {
+	case resp.StatusCode >= 500:
+		return "", &retryableError{fmt.Errorf("server returned %s", resp.Status)}
+	case resp.StatusCode != http.StatusOK:
+		return "", fmt.Errorf("server returned %s", resp.Status)
+	case err != nil:
+		return "", &retryableError{err}
+	}
+	return strings.TrimSuffix(string(body), "\n"), nil
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a Client for baseURL that retries without delay
+func newTestClient(baseURL string) *Client {
+	c := NewClient(baseURL)
+	c.Backoff = time.Millisecond
+	return c
+}
+
+// flakyServer fails the first failures requests with status, then serves
+// greetings
+func flakyServer(t *testing.T, failures int32, status int) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	handler := newTestServer().Handler()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			http.Error(w, "unavailable", status)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	t.Cleanup(ts.Close)
+	return ts, &calls
+}
+
+func TestClientGreet(t *testing.T) {
+	ts, _ := flakyServer(t, 0, 0)
+	client := newTestClient(ts.URL)
+
+	for _, tt := range []struct{ input, expected string }{
+		{"", "Hello, World!"},
+		{"Gopher", "Hello, Gopher!"},
+		{"Mary Jane & co", "Hello, Mary Jane & co!"},
+	} {
+		greeting, err := client.Greet(context.Background(), tt.input)
+		if err != nil {
+			t.Fatalf("Greet(%q) error: %v", tt.input, err)
+		}
+		if greeting != tt.expected {
+			t.Errorf("Greet(%q) = %q, want %q", tt.input, greeting, tt.expected)
+		}
+	}
+}
+
+func TestClientRetriesServerErrors(t *testing.T) {
+	ts, calls := flakyServer(t, 2, http.StatusInternalServerError)
+	client := newTestClient(ts.URL)
+
+	greeting, err := client.Greet(context.Background(), "Gopher")
+	if err != nil {
+		t.Fatalf("Greet error: %v", err)
+	}
+	if greeting != "Hello, Gopher!" {
+		t.Errorf("Greet = %q, want %q", greeting, "Hello, Gopher!")
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("server saw %d requests, want 3", got)
+	}
+}
+
+func TestClientGivesUpAfterMaxAttempts(t *testing.T) {
+	ts, calls := flakyServer(t, 10, http.StatusServiceUnavailable)
+	client := newTestClient(ts.URL)
+	client.MaxAttempts = 2
+
+	_, err := client.Greet(context.Background(), "Gopher")
+	if err == nil || !strings.Contains(err.Error(), "503") {
+		t.Fatalf("Greet error = %v, want 503 failure", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("server saw %d requests, want 2", got)
+	}
+}
+
+func TestClientDoesNotRetryClientErrors(t *testing.T) {
+	ts, calls := flakyServer(t, 10, http.StatusBadRequest)
+	client := newTestClient(ts.URL)
+
+	if _, err := client.Greet(context.Background(), "Gopher"); err == nil {
+		t.Fatal("Greet returned nil error for a 400 response")
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("server saw %d requests, want 1", got)
+	}
+}
+
+func TestClientRetriesNetworkErrors(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	url := ts.URL
+	ts.Close()
+
+	client := newTestClient(url)
+	start := time.Now()
+	_, err := client.Greet(context.Background(), "Gopher")
+	if err == nil {
+		t.Fatal("Greet returned nil error for a closed server")
+	}
+	if !strings.Contains(err.Error(), "attempt 3") {
+		t.Errorf("Greet error = %v, want it to report 3 attempts", err)
+	}
+	if elapsed := time.Since(start); elapsed < 3*time.Millisecond {
+		t.Errorf("Greet returned after %v, want at least the backoff delays", elapsed)
+	}
+}
+
+func TestClientHonorsContextDuringBackoff(t *testing.T) {
+	ts, calls := flakyServer(t, 10, http.StatusInternalServerError)
+	client := newTestClient(ts.URL)
+	client.Backoff = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := client.Greet(ctx, "Gopher")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Greet error = %v, want %v", err, context.DeadlineExceeded)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("server saw %d requests, want 1", got)
+	}
+}
@@ -0,0 +1,17 @@
+package main
+
+import "time"
+
+// Clock tells the current time. Tests substitute a fake clock to make
+// time-dependent behavior deterministic.
+type Clock interface {
+	Now() time.Time
+}
+
+// realClock is the Clock backed by time.Now
+type realClock struct{}
+
+// Now returns the current local time
+func (realClock) Now() time.Time {
+	return time.Now()
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+
+	"golang.org/x/term"
+)
+
+// Color modes accepted by the -color flag
+const (
+	colorAuto   = "auto"
+	colorAlways = "always"
+	colorNever  = "never"
+)
+
+// ANSI escape sequences used to colorize greetings
+const (
+	ansiGreeting = "\x1b[1;32m"
+	ansiReset    = "\x1b[0m"
+)
+
+// ansiPattern matches ANSI CSI escape sequences such as color codes
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]`)
+
+// ColorizeGreeting wraps s in ANSI color codes when enabled. When disabled
+// it strips any escape sequences already present in s.
+func ColorizeGreeting(s string, enabled bool) string {
+	s = stripANSI(s)
+	if !enabled {
+		return s
+	}
+	return ansiGreeting + s + ansiReset
+}
+
+// stripANSI removes ANSI escape sequences from s
+func stripANSI(s string) string {
+	return ansiPattern.ReplaceAllString(s, "")
+}
+
+// fdWriter is a writer backed by a file descriptor, such as *os.File
+type fdWriter interface {
+	io.Writer
+	Fd() uintptr
+}
+
+// ErrUnknownColorMode is returned for a -color mode other than auto,
+// always or never
+var ErrUnknownColorMode = errors.New("unknown color mode")
+
+// colorEnabled resolves a -color mode for output written to w. In auto mode
+// color is used only when w is a terminal.
+func colorEnabled(mode string, w io.Writer) (bool, error) {
+	switch mode {
+	case colorAlways:
+		return true, nil
+	case colorNever:
+		return false, nil
+	case colorAuto:
+		f, ok := w.(fdWriter)
+		return ok && term.IsTerminal(int(f.Fd())), nil
+	}
+	return false, fmt.Errorf("%w %q (want %s, %s or %s)", ErrUnknownColorMode, mode, colorAuto, colorAlways, colorNever)
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestColorizeGreeting(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		enabled  bool
+		expected string
+	}{
+		{"enabled", "Hello, Alice!", true, "\x1b[1;32mHello, Alice!\x1b[0m"},
+		{"disabled", "Hello, Alice!", false, "Hello, Alice!"},
+		{"disabled strips color", "\x1b[31mHello\x1b[0m, Alice!", false, "Hello, Alice!"},
+		{"enabled replaces existing color", "\x1b[31mHello, Alice!\x1b[0m", true, "\x1b[1;32mHello, Alice!\x1b[0m"},
+		{"disabled strips cursor codes", "\x1b[2KHello\x1b[?25l", false, "Hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ColorizeGreeting(tt.input, tt.enabled)
+			if result != tt.expected {
+				t.Errorf("ColorizeGreeting(%q, %v) = %q, want %q", tt.input, tt.enabled, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestColorEnabled(t *testing.T) {
+	f, err := os.Create(filepath.Join(t.TempDir(), "out"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	tests := []struct {
+		mode     string
+		expected bool
+	}{
+		{colorAlways, true},
+		{colorNever, false},
+		{colorAuto, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			result, err := colorEnabled(tt.mode, f)
+			if err != nil {
+				t.Fatalf("colorEnabled(%q) error: %v", tt.mode, err)
+			}
+			if result != tt.expected {
+				t.Errorf("colorEnabled(%q) = %v, want %v", tt.mode, result, tt.expected)
+			}
+		})
+	}
+
+	if enabled, _ := colorEnabled(colorAuto, &bytes.Buffer{}); enabled {
+		t.Error("colorEnabled(auto) for a non-file writer = true, want false")
+	}
+	if _, err := colorEnabled("sometimes", f); !errors.Is(err, ErrUnknownColorMode) {
+		t.Errorf("colorEnabled with unknown mode error = %v, want ErrUnknownColorMode", err)
+	}
+}
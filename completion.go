@@ -0,0 +1,113 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ErrUnsupportedShell is returned by GenerateCompletion for a shell it
+// cannot generate a script for
+var ErrUnsupportedShell = errors.New("unsupported completion shell")
+
+// completionShells lists the shells GenerateCompletion supports
+var completionShells = []string{"bash", "zsh", "fish"}
+
+// completionFlag describes one flag for a completion script
+type completionFlag struct {
+	name  string
+	usage string
+	// takesValue is false for boolean flags
+	takesValue bool
+}
+
+// completionFlags returns the command-line flags in lexical order
+func completionFlags() []completionFlag {
+	fs, _ := newFlagSet(io.Discard)
+	var flags []completionFlag
+	fs.VisitAll(func(f *flag.Flag) {
+		_, usage := flag.UnquoteUsage(f)
+		b, ok := f.Value.(interface{ IsBoolFlag() bool })
+		flags = append(flags, completionFlag{
+			name:       f.Name,
+			usage:      usage,
+			takesValue: !ok || !b.IsBoolFlag(),
+		})
+	})
+	return flags
+}
+
+// GenerateCompletion writes a script completing the command's flags for
+// shell, one of bash, zsh or fish
+func GenerateCompletion(shell string, w io.Writer) error {
+	flags := completionFlags()
+	var script string
+	switch shell {
+	case "bash":
+		script = bashCompletion(flags)
+	case "zsh":
+		script = zshCompletion(flags)
+	case "fish":
+		script = fishCompletion(flags)
+	default:
+		return fmt.Errorf("%w %q (want %s)", ErrUnsupportedShell, shell, strings.Join(completionShells, ", "))
+	}
+	_, err := io.WriteString(w, script)
+	return err
+}
+
+// bashCompletion completes flag names, falling back to file names
+func bashCompletion(flags []completionFlag) string {
+	words := make([]string, len(flags))
+	for i, f := range flags {
+		words[i] = "-" + f.name
+	}
+	fn := "_" + strings.ReplaceAll(binaryName, "-", "_")
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# bash completion for %s\n", binaryName)
+	fmt.Fprintf(&b, "%s() {\n", fn)
+	b.WriteString("\tlocal cur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+	b.WriteString("\tif [[ $cur == -* ]]; then\n")
+	fmt.Fprintf(&b, "\t\tCOMPREPLY=($(compgen -W \"%s\" -- \"$cur\"))\n", strings.Join(words, " "))
+	b.WriteString("\tfi\n")
+	b.WriteString("}\n")
+	fmt.Fprintf(&b, "complete -o default -F %s %s\n", fn, binaryName)
+	return b.String()
+}
+
+// zshCompletion completes flag names with their descriptions
+func zshCompletion(flags []completionFlag) string {
+	escape := strings.NewReplacer(`'`, `'\''`, `[`, `\[`, `]`, `\]`)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "#compdef %s\n\n", binaryName)
+	b.WriteString("_arguments \\\n")
+	for _, f := range flags {
+		spec := fmt.Sprintf("-%s[%s]", f.name, escape.Replace(f.usage))
+		if f.takesValue {
+			spec += ":" + f.name + ":"
+		}
+		fmt.Fprintf(&b, "\t'%s' \\\n", spec)
+	}
+	b.WriteString("\t'*:name:'\n")
+	return b.String()
+}
+
+// fishCompletion completes flag names with their descriptions
+func fishCompletion(flags []completionFlag) string {
+	escape := strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# fish completion for %s\n", binaryName)
+	for _, f := range flags {
+		fmt.Fprintf(&b, "complete -c %s -o %s -d '%s'", binaryName, f.name, escape.Replace(f.usage))
+		if f.takesValue {
+			b.WriteString(" -r")
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
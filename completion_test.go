@@ -0,0 +1,59 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestGenerateCompletion(t *testing.T) {
+	tests := []struct {
+		shell string
+		want  []string
+	}{
+		{"bash", []string{"complete -o default -F _codex_universal codex-universal", "-name", "-locale", "-format", "-completion", "-shutdown-timeout"}},
+		{"zsh", []string{"#compdef codex-universal", "'-name[name to greet (defaults to World)]:name:'", "'-shout[print the greeting in upper case]'", "-completion["}},
+		{"fish", []string{"complete -c codex-universal -o name -d 'name to greet (defaults to World)' -r", "complete -c codex-universal -o shout -d 'print the greeting in upper case'\n", "-o completion"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.shell, func(t *testing.T) {
+			var b strings.Builder
+			if err := GenerateCompletion(tt.shell, &b); err != nil {
+				t.Fatalf("GenerateCompletion(%q) error: %v", tt.shell, err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(b.String(), want) {
+					t.Errorf("GenerateCompletion(%q) output does not contain %q:\n%s", tt.shell, want, b.String())
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateCompletionCoversFlags(t *testing.T) {
+	var b strings.Builder
+	if err := GenerateCompletion("bash", &b); err != nil {
+		t.Fatalf("GenerateCompletion error: %v", err)
+	}
+	words := make(map[string]bool)
+	for _, w := range strings.Fields(b.String()) {
+		words[strings.Trim(w, `"`)] = true
+	}
+	for _, f := range completionFlags() {
+		if !words["-"+f.name] {
+			t.Errorf("bash completion is missing flag -%s", f.name)
+		}
+	}
+}
+
+func TestGenerateCompletionUnsupportedShell(t *testing.T) {
+	var b strings.Builder
+	err := GenerateCompletion("powershell", &b)
+	if !errors.Is(err, ErrUnsupportedShell) || !strings.Contains(err.Error(), `unsupported completion shell "powershell"`) {
+		t.Errorf("GenerateCompletion(powershell) error = %v, want unsupported shell error", err)
+	}
+	if b.Len() != 0 {
+		t.Errorf("GenerateCompletion(powershell) wrote %q, want nothing", b.String())
+	}
+}
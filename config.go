@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds defaults loaded from a config file. Empty fields leave the
+// corresponding flag default untouched.
+type Config struct {
+	DefaultName string `json:"default_name" yaml:"default_name"`
+	Locale      string `json:"locale" yaml:"locale"`
+	Format      string `json:"format" yaml:"format"`
+	Template    string `json:"template" yaml:"template"`
+}
+
+// Redacted returns a copy of c that is safe to expose, with DefaultName,
+// which names a person, blanked
+func (c *Config) Redacted() *Config {
+	r := *c
+	r.DefaultName = ""
+	return &r
+}
+
+// LoadConfig reads a JSON or YAML config file, chosen by its extension
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("load config: %w", err)
+	}
+
+	cfg := &Config{}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return cfg, nil
+	}
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		err = dec.Decode(cfg)
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		err = dec.Decode(cfg)
+	default:
+		return nil, fmt.Errorf("load config %s: unsupported extension %q (want .json, .yaml or .yml)", path, ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Environment variables read by ConfigFromEnv
+const (
+	envName   = "CODEX_NAME"
+	envLocale = "CODEX_LOCALE"
+	envFormat = "CODEX_FORMAT"
+)
+
+// ConfigFromEnv returns the defaults set in the CODEX_NAME, CODEX_LOCALE and
+// CODEX_FORMAT environment variables. Unset and empty variables leave the
+// corresponding field empty.
+func ConfigFromEnv() *Config {
+	return &Config{
+		DefaultName: os.Getenv(envName),
+		Locale:      os.Getenv(envLocale),
+		Format:      os.Getenv(envFormat),
+	}
+}
+
+// flagFields maps flag names to the config fields that default them
+func (c *Config) flagFields() map[string]*string {
+	return map[string]*string{
+		"name":     &c.DefaultName,
+		"locale":   &c.Locale,
+		"format":   &c.Format,
+		"template": &c.Template,
+	}
+}
+
+// applyConfig sets flags in fs from cfg, skipping flags that were set
+// explicitly on the command line so that they take precedence
+func applyConfig(fs *flag.FlagSet, cfg *Config) error {
+	explicit := make(map[string]bool)
+	fs.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+
+	for name, value := range cfg.flagFields() {
+		if *value == "" || explicit[name] || fs.Lookup(name) == nil {
+			continue
+		}
+		if err := fs.Set(name, *value); err != nil {
+			return fmt.Errorf("config %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// explicitConfig returns the config values of the flags set in fs, whether
+// on the command line or by applyConfig
+func explicitConfig(fs *flag.FlagSet) *Config {
+	cfg := &Config{}
+	fields := cfg.flagFields()
+	fs.Visit(func(f *flag.Flag) {
+		if field, ok := fields[f.Name]; ok {
+			*field = f.Value.String()
+		}
+	})
+	return cfg
+}
+
+// overriddenBy returns a copy of c with the non-empty fields of o replacing
+// its own, the same precedence applyConfig gives explicit flags
+func (c *Config) overriddenBy(o *Config) *Config {
+	r := *c
+	if o == nil {
+		return &r
+	}
+	fields := r.flagFields()
+	for name, value := range o.flagFields() {
+		if *value != "" {
+			*fields[name] = *value
+		}
+	}
+	return &r
+}
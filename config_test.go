@@ -0,0 +1,177 @@
+package main
+
+import (
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeConfig writes content to a file named name in a temporary directory
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("writing config: %v", err)
+	}
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	full := Config{DefaultName: "Alice", Locale: "es", Format: "json", Template: "Hey, {{.Name}}!"}
+
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		expected Config
+	}{
+		{"json", "config.json", `{"default_name":"Alice","locale":"es","format":"json","template":"Hey, {{.Name}}!"}`, full},
+		{"yaml", "config.yaml", "default_name: Alice\nlocale: es\nformat: json\ntemplate: \"Hey, {{.Name}}!\"\n", full},
+		{"yml", "config.yml", "locale: de\n", Config{Locale: "de"}},
+		{"empty json", "config.json", "", Config{}},
+		{"empty yaml", "config.yaml", "\n", Config{}},
+		{"empty object", "config.json", "{}", Config{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := LoadConfig(writeConfig(t, tt.file, tt.content))
+			if err != nil {
+				t.Fatalf("LoadConfig error: %v", err)
+			}
+			if *cfg != tt.expected {
+				t.Errorf("LoadConfig = %+v, want %+v", *cfg, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    func(t *testing.T) string
+		wantErr string
+	}{
+		{"missing file", func(t *testing.T) string { return filepath.Join(t.TempDir(), "missing.json") }, "no such file"},
+		{"unsupported extension", func(t *testing.T) string { return writeConfig(t, "config.toml", "locale = 'es'") }, "unsupported extension"},
+		{"malformed json", func(t *testing.T) string { return writeConfig(t, "config.json", "{") }, "load config"},
+		{"unknown field", func(t *testing.T) string { return writeConfig(t, "config.yaml", "colour: red\n") }, "colour"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadConfig(tt.path(t))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadConfig error = %v, want it to contain %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestApplyConfigPrecedence(t *testing.T) {
+	cfg := &Config{DefaultName: "Alice", Locale: "es", Format: "json"}
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantName   string
+		wantLocale string
+		wantFormat string
+	}{
+		{"config only", nil, "Alice", "es", "json"},
+		{"flag overrides config", []string{"-name", "Bob", "-format", "text"}, "Bob", "es", "text"},
+		{"explicit empty flag wins", []string{"-name", ""}, "", "es", "json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			name := fs.String("name", "", "")
+			locale := fs.String("locale", "", "")
+			format := fs.String("format", formatText, "")
+			if err := fs.Parse(tt.args); err != nil {
+				t.Fatalf("parse: %v", err)
+			}
+			if err := applyConfig(fs, cfg); err != nil {
+				t.Fatalf("applyConfig error: %v", err)
+			}
+			if *name != tt.wantName || *locale != tt.wantLocale || *format != tt.wantFormat {
+				t.Errorf("got name=%q locale=%q format=%q, want name=%q locale=%q format=%q",
+					*name, *locale, *format, tt.wantName, tt.wantLocale, tt.wantFormat)
+			}
+		})
+	}
+}
+
+func TestApplyEmptyConfig(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	format := fs.String("format", formatText, "")
+	if err := applyConfig(fs, &Config{}); err != nil {
+		t.Fatalf("applyConfig error: %v", err)
+	}
+	if *format != formatText {
+		t.Errorf("format = %q after empty config, want default %q", *format, formatText)
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("CODEX_NAME", "Eve")
+	t.Setenv("CODEX_LOCALE", "")
+	t.Setenv("CODEX_FORMAT", "json")
+
+	expected := Config{DefaultName: "Eve", Format: "json"}
+	if cfg := ConfigFromEnv(); *cfg != expected {
+		t.Errorf("ConfigFromEnv() = %+v, want %+v", *cfg, expected)
+	}
+}
+
+func TestConfigRedacted(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+		want Config
+	}{
+		{"empty", Config{}, Config{}},
+		{"default name", Config{DefaultName: "Ann", Locale: "fr", Format: "json", Template: "Hey, {{.Name}}!"},
+			Config{Locale: "fr", Format: "json", Template: "Hey, {{.Name}}!"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tt.cfg
+			if got := cfg.Redacted(); *got != tt.want {
+				t.Errorf("Redacted() = %+v, want %+v", *got, tt.want)
+			}
+			if cfg != tt.cfg {
+				t.Errorf("Redacted modified its receiver: %+v", cfg)
+			}
+		})
+	}
+}
+
+func TestExplicitConfig(t *testing.T) {
+	t.Setenv("CODEX_NAME", "")
+	t.Setenv("CODEX_LOCALE", "")
+	t.Setenv("CODEX_FORMAT", "json")
+
+	fs, _ := newFlagSet(io.Discard)
+	if err := fs.Parse([]string{"-locale", "de", "-count", "2"}); err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if err := applyConfig(fs, ConfigFromEnv()); err != nil {
+		t.Fatalf("applyConfig: %v", err)
+	}
+	expected := Config{Locale: "de", Format: "json"}
+	if got := explicitConfig(fs); *got != expected {
+		t.Errorf("explicitConfig = %+v, want %+v", *got, expected)
+	}
+
+	file := &Config{DefaultName: "Ann", Locale: "es", Format: "text"}
+	want := Config{DefaultName: "Ann", Locale: "de", Format: "json"}
+	if got := file.overriddenBy(explicitConfig(fs)); *got != want {
+		t.Errorf("overriddenBy = %+v, want %+v", *got, want)
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// csvHeader is the header row written by HelloCSV
+var csvHeader = []string{"name", "greeting"}
+
+// HelloCSV reads a single-column CSV of names from r and writes a
+// name,greeting CSV to w, starting with a header row. A first input row
+// reading "name" is taken as a header and skipped. Empty cells are greeted
+// with the default. Note that encoding/csv skips blank lines; an empty name
+// must be written as "".
+func HelloCSV(r io.Reader, w io.Writer) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = 1
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for first := true; ; first = false {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("read csv: %w", err)
+		}
+		name := record[0]
+		if first && strings.EqualFold(strings.TrimSpace(name), "name") {
+			continue
+		}
+		if err := cw.Write([]string{name, Hello(name)}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestHelloCSV(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"plain names", "Alice\nBob\n", "name,greeting\nAlice,\"Hello, Alice!\"\nBob,\"Hello, Bob!\"\n"},
+		{"header row", "name\nAlice\n", "name,greeting\nAlice,\"Hello, Alice!\"\n"},
+		{"header row mixed case", "Name\r\nAlice\r\n", "name,greeting\nAlice,\"Hello, Alice!\"\n"},
+		{"name only in first row is header", "Alice\nname\n", "name,greeting\nAlice,\"Hello, Alice!\"\nname,\"Hello, name!\"\n"},
+		{"quoted name with comma", "\"Smith, John\"\n", "name,greeting\n\"Smith, John\",\"Hello, Smith, John!\"\n"},
+		{"quoted name with quotes", "\"Bob \"\"The Builder\"\"\"\n", "name,greeting\n\"Bob \"\"The Builder\"\"\",\"Hello, Bob \"\"The Builder\"\"!\"\n"},
+		{"empty cell", "Alice\n\"\"\nBob\n", "name,greeting\nAlice,\"Hello, Alice!\"\n,\"Hello, World!\"\nBob,\"Hello, Bob!\"\n"},
+		{"empty input", "", "name,greeting\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := HelloCSV(strings.NewReader(tt.input), &buf); err != nil {
+				t.Fatalf("HelloCSV error: %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("HelloCSV output = %q, want %q", buf.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestHelloCSVErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{"two columns", "Alice,Bob\n"},
+		{"unterminated quote", "\"Alice\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := HelloCSV(strings.NewReader(tt.input), &bytes.Buffer{}); err == nil {
+				t.Errorf("HelloCSV(%q) returned nil error", tt.input)
+			}
+		})
+	}
+}
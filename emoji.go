@@ -0,0 +1,15 @@
+package main
+
+// wavingHand is the emoji appended by HelloEmoji, U+1F44B
+const wavingHand = "\U0001F44B"
+
+// HelloEmoji returns the greeting for name followed by a waving hand,
+// e.g. "Hello, Alice! 👋"
+func HelloEmoji(name string) string {
+	return withEmoji(Hello(name))
+}
+
+// withEmoji appends a waving hand to greeting
+func withEmoji(greeting string) string {
+	return greeting + " " + wavingHand
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestHelloEmoji(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty name", "", "Hello, World! 👋"},
+		{"with name", "Alice", "Hello, Alice! 👋"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := HelloEmoji(tt.input); result != tt.expected {
+				t.Errorf("HelloEmoji(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHelloEmojiEncoding(t *testing.T) {
+	result := HelloEmoji("Alice")
+	suffix := []byte{' ', 0xF0, 0x9F, 0x91, 0x8B}
+	if !bytes.HasSuffix([]byte(result), suffix) {
+		t.Errorf("HelloEmoji bytes = % x, want suffix % x", result, suffix)
+	}
+	if !utf8.ValidString(result) {
+		t.Errorf("HelloEmoji(%q) is not valid UTF-8", result)
+	}
+	// "Hello, Alice!" is 13 bytes; the space and 4-byte emoji add 5 bytes
+	// but only 2 runes.
+	if got, want := len(result), len("Hello, Alice!")+5; got != want {
+		t.Errorf("len(HelloEmoji) = %d, want %d", got, want)
+	}
+	if got, want := utf8.RuneCountInString(result), len("Hello, Alice!")+2; got != want {
+		t.Errorf("rune count of HelloEmoji = %d, want %d", got, want)
+	}
+}
+
+func TestHelloEmojiJSON(t *testing.T) {
+	data, err := json.Marshal(Greeting{Name: "Alice", Greeting: HelloEmoji("Alice")})
+	if err != nil {
+		t.Fatalf("json.Marshal error: %v", err)
+	}
+	if !json.Valid(data) {
+		t.Fatalf("json.Marshal produced invalid JSON: %s", data)
+	}
+	var got Greeting
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("json.Unmarshal error: %v", err)
+	}
+	if got.Greeting != HelloEmoji("Alice") {
+		t.Errorf("round-tripped greeting = %q, want %q", got.Greeting, HelloEmoji("Alice"))
+	}
+}
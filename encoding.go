@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/japanese"
+	"golang.org/x/text/transform"
+)
+
+// Input encodings understood by HelloFromEncoding
+const (
+	encodingUTF8     = "utf-8"
+	encodingLatin1   = "latin1"
+	encodingShiftJIS = "shift-jis"
+)
+
+// ErrUnknownEncoding is returned for an input encoding HelloFromEncoding
+// does not support
+var ErrUnknownEncoding = errors.New("unknown encoding")
+
+// ErrInvalidEncoding is matched by the error HelloFromEncoding returns for
+// input bytes that are not valid in the declared encoding
+var ErrInvalidEncoding = errors.New("invalid input for encoding")
+
+// encodingAliases maps accepted encoding names to their canonical name
+var encodingAliases = map[string]string{
+	"utf-8":      encodingUTF8,
+	"utf8":       encodingUTF8,
+	"latin1":     encodingLatin1,
+	"latin-1":    encodingLatin1,
+	"iso-8859-1": encodingLatin1,
+	"shift-jis":  encodingShiftJIS,
+	"shift_jis":  encodingShiftJIS,
+	"sjis":       encodingShiftJIS,
+}
+
+// decoders returns the x/text encoding for each canonical name; UTF-8 input
+// is only validated, not transcoded
+var decoders = map[string]encoding.Encoding{
+	encodingLatin1:   charmap.ISO8859_1,
+	encodingShiftJIS: japanese.ShiftJIS,
+}
+
+// HelloFromEncoding is like HelloStream for input in the named encoding,
+// one of utf-8, latin1 or shift-jis (case-insensitive, with common
+// aliases). Each line is transcoded to UTF-8 before it is greeted. A line
+// that is not valid in the encoding stops the stream with an error wrapping
+// ErrInvalidEncoding; greetings for earlier lines are still written.
+func HelloFromEncoding(r io.Reader, name string, w io.Writer) error {
+	dr, err := newDecodingReader(r, name)
+	if err != nil {
+		return err
+	}
+	return HelloStream(dr, w)
+}
+
+// decodingReader transcodes input to UTF-8 a line at a time, so that a
+// line that is not valid in its encoding is never passed on, even in part
+type decodingReader struct {
+	br       *bufio.Reader
+	encoding string
+	// replaced is set for legacy decoders, which substitute U+FFFD for
+	// invalid bytes
+	replaced bool
+	line     int
+	buf      []byte
+	pending  []byte
+	err      error
+}
+
+// newDecodingReader returns a reader of r, in the named encoding, as UTF-8,
+// or an error wrapping ErrUnknownEncoding
+func newDecodingReader(r io.Reader, name string) (*decodingReader, error) {
+	canonical, ok := encodingAliases[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return nil, fmt.Errorf("%w %q (want %s, %s or %s)", ErrUnknownEncoding, name, encodingUTF8, encodingLatin1, encodingShiftJIS)
+	}
+	var t transform.Transformer = encoding.UTF8Validator
+	if e := decoders[canonical]; e != nil {
+		t = e.NewDecoder()
+	}
+	return &decodingReader{
+		br:       bufio.NewReader(transform.NewReader(r, t)),
+		encoding: canonical,
+		replaced: t != encoding.UTF8Validator,
+	}, nil
+}
+
+func (d *decodingReader) Read(p []byte) (int, error) {
+	for len(d.pending) == 0 {
+		if d.err != nil {
+			return 0, d.err
+		}
+		d.fill()
+	}
+	n := copy(p, d.pending)
+	d.pending = d.pending[n:]
+	return n, nil
+}
+
+// fill decodes the next line into pending, or records in err why there is
+// none. Lines longer than maxLineSize are passed on unchecked for the
+// scanner reading d to reject.
+func (d *decodingReader) fill() {
+	d.buf = d.buf[:0]
+	for {
+		chunk, err := d.br.ReadSlice('\n')
+		d.buf = append(d.buf, chunk...)
+		if err == bufio.ErrBufferFull {
+			if len(d.buf) <= maxLineSize {
+				continue
+			}
+			d.pending = d.buf
+			return
+		}
+		d.line++
+		if errors.Is(err, encoding.ErrInvalidUTF8) {
+			d.err = d.invalid(err)
+			return
+		}
+		if i := bytes.IndexRune(d.buf, utf8.RuneError); d.replaced && i >= 0 {
+			// None of the legacy encodings can represent U+FFFD itself
+			d.err = d.invalid(fmt.Errorf("undecodable bytes near byte %d", i))
+			return
+		}
+		d.pending, d.err = d.buf, err
+		return
+	}
+}
+
+// invalid returns the error for the current line failing to decode
+func (d *decodingReader) invalid(err error) error {
+	return fmt.Errorf("line %d: %w %s: %w", d.line, ErrInvalidEncoding, d.encoding, err)
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestHelloFromEncoding(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoding string
+		input    string
+		expected string
+	}{
+		{"utf-8", "utf-8", "José\n\nこんにちは\n", "Hello, José!\nHello, World!\nHello, こんにちは!\n"},
+		{"latin1", "latin1", "Jos\xe9\nZo\xeb\n", "Hello, José!\nHello, Zoë!\n"},
+		{"latin1 alias", "ISO-8859-1", "Fran\xe7ois\n", "Hello, François!\n"},
+		{"shift-jis", "shift-jis", "\x8eR\x93c\x91\xbe\x98Y\n", "Hello, 山田太郎!\n"},
+		{"shift-jis alias", "Shift_JIS", "\x82\xb1\x82\xf1\x82\xc9\x82\xbf\x82\xcd\n", "Hello, こんにちは!\n"},
+		{"ascii in shift-jis", "sjis", "Alice\n", "Hello, Alice!\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			if err := HelloFromEncoding(strings.NewReader(tt.input), tt.encoding, &out); err != nil {
+				t.Fatalf("HelloFromEncoding error: %v", err)
+			}
+			if out.String() != tt.expected {
+				t.Errorf("HelloFromEncoding(%q, %s) = %q, want %q", tt.input, tt.encoding, out.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestHelloFromEncodingInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		encoding string
+		input    string
+		wantErr  string
+		written  string
+	}{
+		{"latin1 read as utf-8", "utf-8", "Alice\nJos\xe9\n", "line 2: invalid input for encoding utf-8", "Hello, Alice!\n"},
+		{"truncated shift-jis", "shift-jis", "Alice\n\x82\n", "line 2: invalid input for encoding shift-jis", "Hello, Alice!\n"},
+		{"invalid shift-jis byte", "shift-jis", "a\xff\n", "line 1: invalid input for encoding shift-jis", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out strings.Builder
+			err := HelloFromEncoding(strings.NewReader(tt.input), tt.encoding, &out)
+			if !errors.Is(err, ErrInvalidEncoding) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("HelloFromEncoding error = %v, want ErrInvalidEncoding containing %q", err, tt.wantErr)
+			}
+			if out.String() != tt.written {
+				t.Errorf("HelloFromEncoding wrote %q before failing, want %q", out.String(), tt.written)
+			}
+		})
+	}
+}
+
+func TestHelloFromEncodingUnknown(t *testing.T) {
+	var out strings.Builder
+	err := HelloFromEncoding(strings.NewReader("Alice\n"), "ebcdic", &out)
+	if !errors.Is(err, ErrUnknownEncoding) {
+		t.Errorf("HelloFromEncoding(ebcdic) error = %v, want ErrUnknownEncoding", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("HelloFromEncoding(ebcdic) wrote %q, want nothing", out.String())
+	}
+}
+
+func TestHelloFromEncodingLongLine(t *testing.T) {
+	name := strings.Repeat("\xe9", 10000)
+	var out strings.Builder
+	if err := HelloFromEncoding(strings.NewReader(name+"\nBob\n"), "latin1", &out); err != nil {
+		t.Fatalf("HelloFromEncoding error: %v", err)
+	}
+	if want := Hello(strings.Repeat("é", 10000)) + "\nHello, Bob!\n"; out.String() != want {
+		t.Errorf("HelloFromEncoding of a %d-byte line wrote %d bytes, want %d", len(name), out.Len(), len(want))
+	}
+
+	err := HelloFromEncoding(strings.NewReader("Alice\n"+strings.Repeat("a", 10000)+"\xff\n"), "shift-jis", &out)
+	if !errors.Is(err, ErrInvalidEncoding) || !strings.Contains(err.Error(), "line 2:") {
+		t.Errorf("HelloFromEncoding error = %v, want ErrInvalidEncoding on line 2", err)
+	}
+}
+
+func TestHelloFromEncodingWriteError(t *testing.T) {
+	w := &limitWriter{n: len("Hello, José!\n"), err: errors.New("broken pipe")}
+	err := HelloFromEncoding(strings.NewReader("Jos\xe9\nZo\xeb\n"), "latin1", w)
+	var streamErr *StreamError
+	if !errors.As(err, &streamErr) || streamErr.Written != 1 {
+		t.Errorf("HelloFromEncoding error = %#v, want *StreamError after 1 line", err)
+	}
+}
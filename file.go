@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// HelloFile greets every line of the file at path. Lines that fail
+// ValidateName are skipped and reported together in the returned error,
+// alongside the greetings for the valid lines.
+func HelloFile(path string) ([]string, error) {
+	return helloFile(path, "", MaxNameLength)
+}
+
+// helloFile is HelloFile for a file in the named encoding, or UTF-8 when
+// encoding is empty, with an explicit maximum name length in runes. A line
+// that is not valid in the encoding ends the file early.
+func helloFile(path, encoding string, maxNameLength int) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("greet file: %w", err)
+	}
+	defer f.Close()
+	var r io.Reader = f
+	if encoding != "" {
+		if r, err = newDecodingReader(f, encoding); err != nil {
+			return nil, fmt.Errorf("greet file: %w", err)
+		}
+	}
+
+	greetings := []string{}
+	var errs []error
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineSize)
+	for line := 1; scanner.Scan(); line++ {
+		name := scanner.Text()
+		if err := validateName(name, maxNameLength); err != nil {
+			errs = append(errs, fmt.Errorf("%s:%d: %w", path, line, err))
+			continue
+		}
+		greetings = append(greetings, Hello(name))
+	}
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, fmt.Errorf("greet file %s: %w", path, err))
+	}
+	return greetings, errors.Join(errs...)
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// writeNames writes content to a names file in a temporary directory
+func writeNames(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "names.txt")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("writing names: %v", err)
+	}
+	return path
+}
+
+func TestHelloFile(t *testing.T) {
+	path := writeNames(t, "Alice\n\nBob\n")
+	greetings, err := HelloFile(path)
+	if err != nil {
+		t.Fatalf("HelloFile error: %v", err)
+	}
+	expected := []string{"Hello, Alice!", "Hello, World!", "Hello, Bob!"}
+	if !slices.Equal(greetings, expected) {
+		t.Errorf("HelloFile = %q, want %q", greetings, expected)
+	}
+}
+
+func TestHelloFileEmpty(t *testing.T) {
+	greetings, err := HelloFile(writeNames(t, ""))
+	if err != nil {
+		t.Fatalf("HelloFile error: %v", err)
+	}
+	if greetings == nil || len(greetings) != 0 {
+		t.Errorf("HelloFile of empty file = %#v, want empty slice", greetings)
+	}
+}
+
+func TestHelloFileInvalidLines(t *testing.T) {
+	path := writeNames(t, "Alice\nBo\x00b\nCarol\n"+strings.Repeat("x", DefaultMaxNameLength+1)+"\nDave\n")
+	greetings, err := HelloFile(path)
+
+	expected := []string{"Hello, Alice!", "Hello, Carol!", "Hello, Dave!"}
+	if !slices.Equal(greetings, expected) {
+		t.Errorf("HelloFile greetings = %q, want %q", greetings, expected)
+	}
+	if err == nil {
+		t.Fatal("HelloFile returned nil error for invalid lines")
+	}
+	msg := err.Error()
+	for _, want := range []string{path + ":2: ", "control character", path + ":4: ", "maximum is"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("HelloFile error %q does not contain %q", msg, want)
+		}
+	}
+	if strings.Contains(msg, ":1:") || strings.Contains(msg, ":3:") || strings.Contains(msg, ":5:") {
+		t.Errorf("HelloFile error %q reports valid lines", msg)
+	}
+	if !errors.Is(err, ErrInvalidName) {
+		t.Errorf("HelloFile error %v does not match ErrInvalidName", err)
+	}
+	var joined interface{ Unwrap() []error }
+	if !errors.As(err, &joined) || len(joined.Unwrap()) != 2 {
+		t.Errorf("HelloFile error is not a join of 2 errors: %#v", err)
+	}
+}
+
+func TestHelloFileMissing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "missing.txt")
+	greetings, err := HelloFile(path)
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("HelloFile error = %v, want %v", err, fs.ErrNotExist)
+	}
+	if err != nil && !strings.Contains(err.Error(), path) {
+		t.Errorf("HelloFile error %q does not name the path", err)
+	}
+	if greetings != nil {
+		t.Errorf("HelloFile greetings = %q, want nil", greetings)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Output formats accepted by the -format flag
+const (
+	formatText = "text"
+	formatJSON = "json"
+)
+
+// Greeting is the structured form of a greeting
+type Greeting struct {
+	Name     string `json:"name"`
+	Greeting string `json:"greeting"`
+}
+
+// NewGreeting returns the structured greeting for name
+func NewGreeting(name string) Greeting {
+	return Greeting{Name: name, Greeting: Hello(name)}
+}
+
+// HelloJSON returns the greeting for name encoded as a JSON object
+func HelloJSON(name string) ([]byte, error) {
+	return json.Marshal(NewGreeting(name))
+}
+
+// ErrUnknownFormat is returned for an unsupported output format
+var ErrUnknownFormat = errors.New("unknown format")
+
+// validateFormat reports whether format is a supported output format
+func validateFormat(format string) error {
+	switch format {
+	case formatText, formatJSON:
+		return nil
+	}
+	return fmt.Errorf("%w %q (want %s or %s)", ErrUnknownFormat, format, formatText, formatJSON)
+}
+
+// writeGreetings writes greetings to w in the given format, one per line for
+// text. JSON output is a single object unless list is set, in which case it
+// is an array. Output is always newline-terminated.
+func writeGreetings(w io.Writer, format string, greetings []Greeting, list bool) error {
+	if err := validateFormat(format); err != nil {
+		return err
+	}
+	if format == formatJSON {
+		enc := json.NewEncoder(w)
+		if list {
+			return enc.Encode(greetings)
+		}
+		return enc.Encode(greetings[0])
+	}
+	for _, g := range greetings {
+		if _, err := fmt.Fprintln(w, g.Greeting); err != nil {
+			return err
+		}
+	}
+	return nil
+}
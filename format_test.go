@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestHelloJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty name", "", `{"name":"","greeting":"Hello, World!"}`},
+		{"with name", "Gopher", `{"name":"Gopher","greeting":"Hello, Gopher!"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := HelloJSON(tt.input)
+			if err != nil {
+				t.Fatalf("HelloJSON(%q) error: %v", tt.input, err)
+			}
+			if string(result) != tt.expected {
+				t.Errorf("HelloJSON(%q) = %s, want %s", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWriteGreetings(t *testing.T) {
+	greetings := []Greeting{NewGreeting("Alice"), NewGreeting("")}
+
+	tests := []struct {
+		name      string
+		format    string
+		greetings []Greeting
+		list      bool
+		expected  string
+	}{
+		{"text single", formatText, greetings[:1], false, "Hello, Alice!\n"},
+		{"text list", formatText, greetings, true, "Hello, Alice!\nHello, World!\n"},
+		{"json single", formatJSON, greetings[:1], false, `{"name":"Alice","greeting":"Hello, Alice!"}` + "\n"},
+		{"json list", formatJSON, greetings, true, `[{"name":"Alice","greeting":"Hello, Alice!"},{"name":"","greeting":"Hello, World!"}]` + "\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeGreetings(&buf, tt.format, tt.greetings, tt.list); err != nil {
+				t.Fatalf("writeGreetings error: %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("writeGreetings output = %q, want %q", buf.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestWriteGreetingsUnknownFormat(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeGreetings(&buf, "xml", []Greeting{NewGreeting("")}, false); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("writeGreetings with unknown format error = %v, want ErrUnknownFormat", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("writeGreetings with unknown format wrote %q", buf.String())
+	}
+}
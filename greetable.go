@@ -0,0 +1,20 @@
+package main
+
+import "reflect"
+
+// Greetable is implemented by types that know the name they are greeted by
+type Greetable interface {
+	GreetingName() string
+}
+
+// HelloOf returns the greeting for g's name. A nil g, including a nil
+// pointer of a type implementing Greetable, is greeted as World.
+func HelloOf(g Greetable) string {
+	if g == nil {
+		return Hello("")
+	}
+	if v := reflect.ValueOf(g); v.Kind() == reflect.Pointer && v.IsNil() {
+		return Hello("")
+	}
+	return Hello(g.GreetingName())
+}
@@ -0,0 +1,45 @@
+package main
+
+import "testing"
+
+// user is a sample caller type implementing Greetable
+type user struct {
+	First, Last string
+}
+
+func (u *user) GreetingName() string {
+	if u.Last == "" {
+		return u.First
+	}
+	return u.First + " " + u.Last
+}
+
+// team implements Greetable on a value receiver
+type team string
+
+func (t team) GreetingName() string { return string(t) + " team" }
+
+func TestHelloOf(t *testing.T) {
+	var nilUser *user
+
+	tests := []struct {
+		name     string
+		input    Greetable
+		expected string
+	}{
+		{"user", &user{First: "Ada", Last: "Lovelace"}, "Hello, Ada Lovelace!"},
+		{"first name only", &user{First: "Ada"}, "Hello, Ada!"},
+		{"empty name", &user{}, "Hello, World!"},
+		{"value receiver", team("Go"), "Hello, Go team!"},
+		{"nil interface", nil, "Hello, World!"},
+		{"nil pointer", nilUser, "Hello, World!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := HelloOf(tt.input); result != tt.expected {
+				t.Errorf("HelloOf(%#v) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package main
+
+// Greeter produces the greeting for a name
+type Greeter interface {
+	Greet(name string) string
+}
+
+// LocaleGreeter is implemented by Greeters that can greet in a requested
+// locale
+type LocaleGreeter interface {
+	Greeter
+	GreetLocale(name, locale string) string
+}
+
+// FallibleGreeter is implemented by Greeters whose greeting can fail, such
+// as template greeters. Their Greet returns an empty greeting on failure.
+type FallibleGreeter interface {
+	Greeter
+	TryGreet(name string) (string, error)
+}
+
+// fallibleLocaleGreeter is a LocaleGreeter whose greeting can fail
+type fallibleLocaleGreeter interface {
+	LocaleGreeter
+	TryGreetLocale(name, locale string) (string, error)
+}
+
+// TryGreet returns the greeting g gives name, and the error producing it
+// when g is a FallibleGreeter
+func TryGreet(g Greeter, name string) (string, error) {
+	if fg, ok := g.(FallibleGreeter); ok {
+		return fg.TryGreet(name)
+	}
+	return g.Greet(name), nil
+}
+
+// GreeterFunc adapts an ordinary function to a Greeter
+type GreeterFunc func(name string) string
+
+// Greet returns f(name)
+func (f GreeterFunc) Greet(name string) string {
+	return f(name)
+}
+
+// DefaultGreeter greets with Hello, or HelloLocale when given a locale
+type DefaultGreeter struct{}
+
+// Greet returns Hello(name)
+func (DefaultGreeter) Greet(name string) string {
+	return Hello(name)
+}
+
+// GreetLocale returns HelloLocale(name, locale)
+func (DefaultGreeter) GreetLocale(name, locale string) string {
+	return HelloLocale(name, locale)
+}
+
+// withLocale returns a Greeter that greets in locale when g supports
+// locales, and g itself otherwise
+func withLocale(g Greeter, locale string) Greeter {
+	lg, ok := g.(LocaleGreeter)
+	if !ok || locale == "" {
+		return g
+	}
+	return localeGreeter{g: lg, locale: locale}
+}
+
+// localeGreeter greets in a fixed locale
+type localeGreeter struct {
+	g      LocaleGreeter
+	locale string
+}
+
+// Greet returns the greeting for name in l.locale
+func (l localeGreeter) Greet(name string) string {
+	return l.g.GreetLocale(name, l.locale)
+}
+
+// TryGreet returns the greeting for name in l.locale and any error
+// producing it
+func (l localeGreeter) TryGreet(name string) (string, error) {
+	if fg, ok := l.g.(fallibleLocaleGreeter); ok {
+		return fg.TryGreetLocale(name, l.locale)
+	}
+	return l.Greet(name), nil
+}
+
+// Transform wraps a Greeter to rewrite the name it is given or the
+// greeting it returns
+type Transform func(Greeter) Greeter
+
+// NormalizeTransform normalizes names with NormalizeName before greeting
+func NormalizeTransform(g Greeter) Greeter {
+	return GreeterFunc(func(name string) string { return g.Greet(NormalizeName(name)) })
+}
+
+// ShoutTransform upper-cases greetings with Shout
+func ShoutTransform(g Greeter) Greeter {
+	return GreeterFunc(func(name string) string { return Shout(g.Greet(name)) })
+}
+
+// ReverseTransform reverses greetings with Reverse
+func ReverseTransform(g Greeter) Greeter {
+	return GreeterFunc(func(name string) string { return Reverse(g.Greet(name)) })
+}
+
+// EmojiTransform appends a waving hand to greetings
+func EmojiTransform(g Greeter) Greeter {
+	return GreeterFunc(func(name string) string { return withEmoji(g.Greet(name)) })
+}
+
+// CompositeGreeter greets with Base wrapped by each of Transforms in turn.
+// Each transform wraps the greeter built so far, so greeting rewrites such
+// as ShoutTransform apply in list order. A nil Base is DefaultGreeter.
+type CompositeGreeter struct {
+	Base       Greeter
+	Transforms []Transform
+}
+
+// Greet returns the transformed greeting for name
+func (c CompositeGreeter) Greet(name string) string {
+	g, _ := c.TryGreet(name)
+	return g
+}
+
+// GreetLocale returns the transformed greeting for name in locale, when the
+// base greeter supports locales
+func (c CompositeGreeter) GreetLocale(name, locale string) string {
+	g, _ := c.TryGreetLocale(name, locale)
+	return g
+}
+
+// TryGreet is like Greet but returns the error, if any, from Base
+func (c CompositeGreeter) TryGreet(name string) (string, error) {
+	return c.try(c.base(), name)
+}
+
+// TryGreetLocale is like GreetLocale but returns the error, if any, from
+// Base
+func (c CompositeGreeter) TryGreetLocale(name, locale string) (string, error) {
+	return c.try(withLocale(c.base(), locale), name)
+}
+
+// try greets name with base wrapped by c.Transforms. The transforms only
+// see greeting strings, so an error from base is captured on the way
+// through; it is local to the call, keeping c safe for concurrent use.
+func (c CompositeGreeter) try(base Greeter, name string) (string, error) {
+	var err error
+	capture := GreeterFunc(func(name string) string {
+		g, gerr := TryGreet(base, name)
+		if err == nil {
+			err = gerr
+		}
+		return g
+	})
+	g := c.wrap(capture).Greet(name)
+	if err != nil {
+		return "", err
+	}
+	return g, nil
+}
+
+// base returns c.Base, defaulting to DefaultGreeter
+func (c CompositeGreeter) base() Greeter {
+	if c.Base == nil {
+		return DefaultGreeter{}
+	}
+	return c.Base
+}
+
+// wrap applies c.Transforms to g in order
+func (c CompositeGreeter) wrap(g Greeter) Greeter {
+	for _, t := range c.Transforms {
+		g = t(g)
+	}
+	return g
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDefaultGreeter(t *testing.T) {
+	var g LocaleGreeter = DefaultGreeter{}
+	for _, name := range []string{"", "Gopher"} {
+		if got, want := g.Greet(name), Hello(name); got != want {
+			t.Errorf("Greet(%q) = %q, want %q", name, got, want)
+		}
+		if got, want := g.GreetLocale(name, "es"), HelloLocale(name, "es"); got != want {
+			t.Errorf("GreetLocale(%q, es) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestCompositeGreeter(t *testing.T) {
+	formal := GreeterFunc(func(name string) string { return HelloVariant(name, Formal) })
+
+	tests := []struct {
+		name     string
+		greeter  CompositeGreeter
+		input    string
+		expected string
+	}{
+		{"no transforms", CompositeGreeter{}, "Alice", "Hello, Alice!"},
+		{"normalize shout emoji", CompositeGreeter{Transforms: []Transform{NormalizeTransform, ShoutTransform, EmojiTransform}}, "  josé ", "HELLO, JOSÉ! 👋"},
+		{"normalize shout emoji empty", CompositeGreeter{Transforms: []Transform{NormalizeTransform, ShoutTransform, EmojiTransform}}, "", "HELLO, WORLD! 👋"},
+		{"emoji then reverse", CompositeGreeter{Transforms: []Transform{EmojiTransform, ReverseTransform}}, "Bob", "👋 !boB ,olleH"},
+		{"reverse then emoji", CompositeGreeter{Transforms: []Transform{ReverseTransform, EmojiTransform}}, "Bob", "!boB ,olleH 👋"},
+		{"custom base", CompositeGreeter{Base: formal, Transforms: []Transform{ShoutTransform}}, "Alice", "GOOD DAY, ALICE."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.greeter.Greet(tt.input); result != tt.expected {
+				t.Errorf("Greet(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCompositeGreeterLocale(t *testing.T) {
+	g := CompositeGreeter{Transforms: []Transform{ShoutTransform}}
+	if result := g.GreetLocale("Ana", "es"); result != "¡HOLA, ANA!" {
+		t.Errorf("GreetLocale = %q, want %q", result, "¡HOLA, ANA!")
+	}
+
+	custom := CompositeGreeter{Base: GreeterFunc(func(name string) string { return "Yo " + name })}
+	if result := custom.GreetLocale("Ana", "es"); result != "Yo Ana" {
+		t.Errorf("GreetLocale with locale-unaware base = %q, want %q", result, "Yo Ana")
+	}
+}
+
+func TestServerCustomGreeter(t *testing.T) {
+	srv := newTestServer()
+	srv.Greeter = CompositeGreeter{Transforms: []Transform{EmojiTransform}}
+	handler := srv.Handler()
+
+	tests := []struct {
+		target   string
+		expected string
+	}{
+		{"/hello?name=Alice", "Hello, Alice! 👋\n"},
+		{"/hello?name=Ana&locale=es", "¡Hola, Ana! 👋\n"},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+		if rec.Body.String() != tt.expected {
+			t.Errorf("GET %s body = %q, want %q", tt.target, rec.Body.String(), tt.expected)
+		}
+	}
+}
+
+func TestCompositeGreeterTryGreet(t *testing.T) {
+	renderErr := errors.New("render failed")
+	failing := GreeterFunc(func(name string) string { return "" })
+	base := fallibleFunc{GreeterFunc: failing, err: renderErr}
+	g := CompositeGreeter{Base: base, Transforms: []Transform{ShoutTransform, EmojiTransform}}
+
+	if got, err := g.TryGreet("Al"); !errors.Is(err, renderErr) || got != "" {
+		t.Errorf("TryGreet = %q, %v, want \"\", %v", got, err, renderErr)
+	}
+	if got, err := TryGreet(CompositeGreeter{Transforms: []Transform{ShoutTransform}}, "Al"); err != nil || got != "HELLO, AL!" {
+		t.Errorf("TryGreet of an infallible greeter = %q, %v, want HELLO, AL!", got, err)
+	}
+	if got, err := TryGreet(withLocale(CompositeGreeter{Base: base}, "es"), "Al"); !errors.Is(err, renderErr) {
+		t.Errorf("TryGreet in a locale = %q, %v, want %v", got, err, renderErr)
+	}
+}
+
+// fallibleFunc is a FallibleGreeter that always fails with err
+type fallibleFunc struct {
+	GreeterFunc
+	err error
+}
+
+func (f fallibleFunc) TryGreet(name string) (string, error) {
+	return "", f.err
+}
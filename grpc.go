@@ -0,0 +1,100 @@
+package main
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative greeterpb/greeter.proto
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"time"
+
+	"github.com/Timmyae/codex-universal/greeterpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// grpcGreeter implements the Greeter gRPC service on top of a Greeter
+type grpcGreeter struct {
+	greeterpb.UnimplementedGreeterServer
+	greeter Greeter
+}
+
+// SayHello greets the requested name, failing with codes.Internal if the
+// greeter cannot
+func (g grpcGreeter) SayHello(_ context.Context, req *greeterpb.HelloRequest) (*greeterpb.HelloReply, error) {
+	greeting, err := TryGreet(g.greeter, req.GetName())
+	if err != nil {
+		return nil, status.Error(codes.Internal, "greeting failed")
+	}
+	return &greeterpb.HelloReply{Greeting: greeting}, nil
+}
+
+// GRPCServer serves the Greeter service over gRPC
+type GRPCServer struct {
+	Addr            string
+	ShutdownTimeout time.Duration
+	Logger          *slog.Logger
+	Greeter         Greeter
+}
+
+// NewGRPCServer returns a GRPCServer listening on addr with default settings
+func NewGRPCServer(addr string) *GRPCServer {
+	return &GRPCServer{
+		Addr:            addr,
+		ShutdownTimeout: DefaultShutdownTimeout,
+		Logger:          slog.Default(),
+		Greeter:         DefaultGreeter{},
+	}
+}
+
+// newGRPCServer returns a grpc.Server with the Greeter service registered,
+// backed by g
+func newGRPCServer(g Greeter) *grpc.Server {
+	srv := grpc.NewServer()
+	greeterpb.RegisterGreeterServer(srv, grpcGreeter{greeter: g})
+	return srv
+}
+
+// ListenAndServe listens on s.Addr and serves until ctx is done
+func (s *GRPCServer) ListenAndServe(ctx context.Context) error {
+	ln, err := net.Listen("tcp", s.Addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ctx, ln)
+}
+
+// Serve serves the Greeter service on ln until ctx is done, then stops
+// gracefully. In-flight RPCs still running after s.ShutdownTimeout are
+// cancelled.
+func (s *GRPCServer) Serve(ctx context.Context, ln net.Listener) error {
+	srv := newGRPCServer(s.Greeter)
+	errc := make(chan error, 1)
+	go func() { errc <- srv.Serve(ln) }()
+	s.Logger.Info("serving gRPC greetings", "addr", ln.Addr().String())
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	s.Logger.Info("shutting down gRPC", "timeout", s.ShutdownTimeout)
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+	timer := time.NewTimer(s.ShutdownTimeout)
+	defer timer.Stop()
+	select {
+	case <-stopped:
+	case <-timer.C:
+		srv.Stop()
+		<-stopped
+		return fmt.Errorf("shutdown: %w", context.DeadlineExceeded)
+	}
+	return <-errc
+}
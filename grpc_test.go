@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Timmyae/codex-universal/greeterpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// dialBufconn returns a Greeter client connected to an in-process server
+// listening on ln
+func dialBufconn(t *testing.T, ln *bufconn.Listener) greeterpb.GreeterClient {
+	t.Helper()
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return ln.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dialing bufconn: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return greeterpb.NewGreeterClient(conn)
+}
+
+func TestGRPCSayHello(t *testing.T) {
+	ln := bufconn.Listen(1 << 20)
+	srv := newGRPCServer(DefaultGreeter{})
+	go srv.Serve(ln)
+	t.Cleanup(srv.Stop)
+	client := dialBufconn(t, ln)
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty name", "", "Hello, World!"},
+		{"with name", "Gopher", "Hello, Gopher!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			reply, err := client.SayHello(ctx, &greeterpb.HelloRequest{Name: tt.input})
+			if err != nil {
+				t.Fatalf("SayHello(%q) error: %v", tt.input, err)
+			}
+			if reply.GetGreeting() != tt.expected {
+				t.Errorf("SayHello(%q) = %q, want %q", tt.input, reply.GetGreeting(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestGRPCServerGracefulShutdown(t *testing.T) {
+	ln := bufconn.Listen(1 << 20)
+	srv := NewGRPCServer("bufnet")
+	srv.Logger = discardLogger()
+	srv.ShutdownTimeout = time.Second
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(ctx, ln) }()
+
+	client := dialBufconn(t, ln)
+	reqCtx, reqCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer reqCancel()
+	if _, err := client.SayHello(reqCtx, &greeterpb.HelloRequest{Name: "Gopher"}); err != nil {
+		t.Fatalf("SayHello error: %v", err)
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Serve returned %v after shutdown, want nil", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve did not return after context cancellation")
+	}
+}
+
+func TestGRPCListenAndServeAddrError(t *testing.T) {
+	srv := NewGRPCServer("not-a-valid-address")
+	srv.Logger = discardLogger()
+	if err := srv.ListenAndServe(context.Background()); err == nil {
+		t.Error("ListenAndServe with invalid address returned nil error")
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultHealthCheckTimeout bounds the -healthcheck request
+const DefaultHealthCheckTimeout = 2 * time.Second
+
+// healthCheckName is the name the health check asks the server to greet
+const healthCheckName = "health"
+
+// HealthCheck asks the greeting server at baseURL, such as
+// http://localhost:8080, to greet "health" and reports an error unless it
+// answers 200 OK with the default greeting, Hello(name), within timeout
+func HealthCheck(baseURL string, timeout time.Duration) error {
+	return checkHealth(baseURL, Hello(healthCheckName), timeout)
+}
+
+// checkHealth is HealthCheck expecting exactly the greeting want. An empty
+// want accepts any greeting, for servers whose greetings vary.
+func checkHealth(baseURL, want string, timeout time.Duration) error {
+	client := &http.Client{Timeout: timeout}
+	target := strings.TrimSuffix(baseURL, "/") + "/hello?name=" + healthCheckName
+	req, err := http.NewRequest(http.MethodGet, target, nil)
+	if err != nil {
+		return fmt.Errorf("health check: %w", err)
+	}
+	req.Header.Set("Accept", "text/plain")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("health check: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+	if err != nil {
+		return fmt.Errorf("health check: reading response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("health check: server returned %s", resp.Status)
+	}
+	if got := strings.TrimSuffix(string(body), "\n"); want != "" && got != want {
+		return fmt.Errorf("health check: unexpected greeting %q, want %q", got, want)
+	}
+	return nil
+}
+
+// healthCheckURL returns the base URL for checking a server listening on
+// addr, connecting to localhost when addr listens on all interfaces
+func healthCheckURL(addr string) (string, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", fmt.Errorf("%w %q: %w", ErrInvalidAddr, addr, err)
+	}
+	if ip := net.ParseIP(host); host == "" || ip != nil && ip.IsUnspecified() {
+		host = "localhost"
+	}
+	return "http://" + net.JoinHostPort(host, port), nil
+}
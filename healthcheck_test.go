@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckHealth(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler http.HandlerFunc
+		want    string
+		wantErr string
+	}{
+		{"healthy", nil, "Hello, health!", ""},
+		{"shouting", func(w http.ResponseWriter, r *http.Request) { fmt.Fprintln(w, "HELLO, HEALTH!") }, "HELLO, HEALTH!", ""},
+		{"reversed", func(w http.ResponseWriter, r *http.Request) { fmt.Fprintln(w, "!htlaeh ,olleH") }, "!htlaeh ,olleH", ""},
+		{"any greeting", func(w http.ResponseWriter, r *http.Request) { fmt.Fprintln(w, "Hey, health!") }, "", ""},
+		{"server error", func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "boom", http.StatusInternalServerError)
+		}, "", "500 Internal Server Error"},
+		{"wrong greeting", func(w http.ResponseWriter, r *http.Request) { fmt.Fprintln(w, "Hello, World!") }, "Hello, health!", `unexpected greeting "Hello, World!"`},
+		{"greeting containing the name", func(w http.ResponseWriter, r *http.Request) { fmt.Fprintln(w, "health: down") }, "Hello, health!", "unexpected greeting"},
+		{"too slow", func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-time.After(time.Second):
+			}
+		}, "", "Timeout"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := tt.handler
+			if handler == nil {
+				handler = newTestServer().Handler().ServeHTTP
+			}
+			ts := httptest.NewServer(handler)
+			defer ts.Close()
+
+			err := checkHealth(ts.URL, tt.want, 100*time.Millisecond)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("checkHealth = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("checkHealth = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHealthCheck(t *testing.T) {
+	healthy := httptest.NewServer(newTestServer().Handler())
+	defer healthy.Close()
+	if err := HealthCheck(healthy.URL, time.Second); err != nil {
+		t.Errorf("HealthCheck of a default server = %v, want nil", err)
+	}
+
+	shouting := newTestServer()
+	shouting.Greeter = CompositeGreeter{Transforms: []Transform{ShoutTransform}}
+	ts := httptest.NewServer(shouting.Handler())
+	defer ts.Close()
+	if err := HealthCheck(ts.URL, time.Second); err == nil {
+		t.Error("HealthCheck of a shouting server = nil, want an unexpected greeting error")
+	}
+}
+
+func TestHealthCheckUnreachable(t *testing.T) {
+	ts := httptest.NewServer(http.NotFoundHandler())
+	url := ts.URL
+	ts.Close()
+
+	if err := HealthCheck(url, time.Second); err == nil {
+		t.Error("HealthCheck against a closed server = nil, want error")
+	}
+}
+
+func TestHealthCheckURL(t *testing.T) {
+	tests := []struct {
+		addr     string
+		expected string
+	}{
+		{":8080", "http://localhost:8080"},
+		{"0.0.0.0:8080", "http://localhost:8080"},
+		{"[::]:8080", "http://localhost:8080"},
+		{"127.0.0.1:9000", "http://127.0.0.1:9000"},
+		{"[::1]:9000", "http://[::1]:9000"},
+		{"greeter.internal:80", "http://greeter.internal:80"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			result, err := healthCheckURL(tt.addr)
+			if err != nil {
+				t.Fatalf("healthCheckURL(%q) error: %v", tt.addr, err)
+			}
+			if result != tt.expected {
+				t.Errorf("healthCheckURL(%q) = %q, want %q", tt.addr, result, tt.expected)
+			}
+		})
+	}
+
+	if _, err := healthCheckURL("localhost"); err == nil {
+		t.Error("healthCheckURL without a port = nil error, want error")
+	}
+}
+
+func TestRunHealthCheck(t *testing.T) {
+	healthy := httptest.NewServer(newTestServer().Handler())
+	defer healthy.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "down", http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+
+	addr := func(ts *httptest.Server) string { return ts.Listener.Addr().String() }
+	if _, stderr, code := runCLI(t, "", "-healthcheck", "-addr", addr(healthy)); code != ExitOK {
+		t.Errorf("healthy exit code = %d, want %d (stderr: %q)", code, ExitOK, stderr)
+	}
+	_, stderr, code := runCLI(t, "", "-healthcheck", "-addr", addr(failing))
+	if code != ExitRuntime {
+		t.Errorf("failing exit code = %d, want %d", code, ExitRuntime)
+	}
+	if !strings.Contains(stderr, "503 Service Unavailable") {
+		t.Errorf("failing stderr = %q, want the status reported", stderr)
+	}
+
+	reversed := newTestServer()
+	reversed.Greeter = CompositeGreeter{Base: DefaultGreeter{}, Transforms: []Transform{ReverseTransform}}
+	rts := httptest.NewServer(reversed.Handler())
+	defer rts.Close()
+	if _, stderr, code := runCLI(t, "", "-healthcheck", "-reverse", "-addr", addr(rts)); code != ExitOK {
+		t.Errorf("-reverse exit code = %d, want %d (stderr: %q)", code, ExitOK, stderr)
+	}
+	if _, _, code := runCLI(t, "", "-healthcheck", "-addr", addr(rts)); code != ExitRuntime {
+		t.Errorf("mismatched flags exit code = %d, want %d", code, ExitRuntime)
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import "sync"
+
+// HistoryStore remembers how many times each name has been greeted.
+// Implementations must be safe for concurrent use.
+type HistoryStore interface {
+	// Record counts one greeting for name
+	Record(name string)
+	// Count returns the number of greetings recorded for name
+	Count(name string) int
+}
+
+// DefaultHistorySize is the number of names a MemoryHistory tracks before
+// evicting the least recently greeted
+const DefaultHistorySize = 10000
+
+// MemoryHistory is an in-memory HistoryStore tracking at most MaxEntries
+// names. Once full it forgets the least recently greeted name, whose count
+// then starts again from zero.
+type MemoryHistory struct {
+	MaxEntries int
+
+	mu     sync.Mutex
+	counts *lru[string, int]
+}
+
+// NewMemoryHistory returns an empty MemoryHistory
+func NewMemoryHistory() *MemoryHistory {
+	return &MemoryHistory{MaxEntries: DefaultHistorySize, counts: newLRU[string, int]()}
+}
+
+// Record counts one greeting for name
+func (h *MemoryHistory) Record(name string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	n, _ := h.counts.get(name)
+	h.counts.put(name, n+1, h.MaxEntries)
+}
+
+// Count returns the number of greetings recorded for name
+func (h *MemoryHistory) Count(name string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	n, _ := h.counts.peek(name)
+	return n
+}
+
+// Len returns the number of names currently tracked
+func (h *MemoryHistory) Len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.counts.len()
+}
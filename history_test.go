@@ -0,0 +1,155 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+func TestMemoryHistory(t *testing.T) {
+	h := NewMemoryHistory()
+	if got := h.Count("Alice"); got != 0 {
+		t.Errorf("Count(Alice) on empty store = %d, want 0", got)
+	}
+	h.Record("Alice")
+	h.Record("Alice")
+	h.Record("Bob")
+	if got := h.Count("Alice"); got != 2 {
+		t.Errorf("Count(Alice) = %d, want 2", got)
+	}
+	if got := h.Count("Bob"); got != 1 {
+		t.Errorf("Count(Bob) = %d, want 1", got)
+	}
+}
+
+func TestMemoryHistoryBounded(t *testing.T) {
+	h := NewMemoryHistory()
+	h.MaxEntries = 2
+
+	h.Record("Alice")
+	h.Record("Bob")
+	h.Record("Alice") // Alice is now most recently greeted
+	h.Record("Carol") // evicts Bob
+
+	if n := h.Len(); n != 2 {
+		t.Errorf("Len() = %d, want 2", n)
+	}
+	if got := h.Count("Alice"); got != 2 {
+		t.Errorf("Count(Alice) = %d, want 2", got)
+	}
+	if got := h.Count("Bob"); got != 0 {
+		t.Errorf("Count(Bob) = %d after eviction, want 0", got)
+	}
+}
+
+func TestMemoryHistoryManyNames(t *testing.T) {
+	h := NewMemoryHistory()
+	for i := range DefaultHistorySize + 500 {
+		h.Record(fmt.Sprintf("name-%d", i))
+	}
+	if n := h.Len(); n != DefaultHistorySize {
+		t.Errorf("Len() = %d, want %d", n, DefaultHistorySize)
+	}
+}
+
+func TestMemoryHistoryConcurrent(t *testing.T) {
+	const workers, perWorker = 10, 200
+	names := []string{"Alice", "Bob"}
+	h := NewMemoryHistory()
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			for j := 0; j < perWorker; j++ {
+				h.Record(name)
+				_ = h.Count(name)
+			}
+		}(names[i%len(names)])
+	}
+	wg.Wait()
+
+	for _, name := range names {
+		if got, want := h.Count(name), workers/len(names)*perWorker; got != want {
+			t.Errorf("Count(%s) = %d, want %d", name, got, want)
+		}
+	}
+}
+
+func TestServerHistory(t *testing.T) {
+	srv := newTestServer()
+	handler := srv.Handler()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/hello?name="+name, nil))
+		}([]string{"Alice", "Bob"}[i%2])
+	}
+	wg.Wait()
+
+	tests := []struct {
+		name     string
+		expected int
+	}{
+		{"Alice", 10},
+		{"Bob", 10},
+		{"Carol", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/history?name="+tt.name, nil))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+			}
+			if want := fmt.Sprintf("%d\n", tt.expected); rec.Body.String() != want {
+				t.Errorf("body = %q, want %q", rec.Body.String(), want)
+			}
+		})
+	}
+}
+
+func TestServerHistoryJSON(t *testing.T) {
+	srv := newTestServer()
+	srv.History.Record("Alice")
+
+	req := httptest.NewRequest(http.MethodGet, "/history?name=Alice", nil)
+	req.Header.Set("Accept", "application/json")
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, req)
+
+	var got struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decoding body %q: %v", rec.Body.String(), err)
+	}
+	if got.Name != "Alice" || got.Count != 1 {
+		t.Errorf("body = %+v, want name Alice and count 1", got)
+	}
+}
+
+// staticHistory is a HistoryStore reporting a fixed count
+type staticHistory int
+
+func (staticHistory) Record(string)      {}
+func (h staticHistory) Count(string) int { return int(h) }
+
+func TestServerHistoryPluggable(t *testing.T) {
+	srv := newTestServer()
+	srv.History = staticHistory(42)
+
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/history?name=Anyone", nil))
+	if rec.Body.String() != "42\n" {
+		t.Errorf("body = %q, want %q", rec.Body.String(), "42\n")
+	}
+}
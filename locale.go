@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// defaultLocale is used when a requested locale is not supported
+const defaultLocale = "en"
+
+// localeGreeting holds the phrases used to greet in one language. The
+// phrases carry no closing punctuation; punctuate adds it for a
+// PunctuationStyle.
+type localeGreeting struct {
+	named   string // format for a named greeting, with a single %s verb
+	world   string // greeting used when no name is given
+	open    string // mark opening an exclamation, such as Spanish ¡
+	exclaim string // mark closing an exclamation
+	period  string // mark closing a statement
+}
+
+// locales maps a language code to its greeting phrases
+var locales = map[string]localeGreeting{
+	"en": {named: "Hello, %s", world: "Hello, World", exclaim: "!", period: "."},
+	"es": {named: "Hola, %s", world: "Hola, Mundo", open: "¡", exclaim: "!", period: "."},
+	"fr": {named: "Bonjour, %s", world: "Bonjour, le monde", exclaim: "!", period: "."},
+	"de": {named: "Hallo, %s", world: "Hallo, Welt", exclaim: "!", period: "."},
+	"ja": {named: "こんにちは、%sさん", world: "こんにちは、世界", exclaim: "！", period: "。"},
+}
+
+// greet returns the greeting for name punctuated in style
+func (lg localeGreeting) greet(name string, style PunctuationStyle) string {
+	body := lg.world
+	if name != "" {
+		body = fmt.Sprintf(lg.named, name)
+	}
+	switch style {
+	case Period:
+		return body + lg.period
+	case None:
+		return body
+	}
+	return lg.open + body + lg.exclaim
+}
+
+// supportedLocales caches SupportedLocales for locale resolution
+var supportedLocales = SupportedLocales()
+
+// lookupLocale returns the supported locale code for locale, or the
+// default locale when it is not supported
+func lookupLocale(locale string) string {
+	return ResolveLocale(locale, supportedLocales)
+}
+
+// ResolveLocale returns the entry of available that best matches the
+// requested BCP 47 tag, falling back from the full tag to shorter prefixes,
+// so es-MX resolves to es. It returns English when nothing matches.
+// Matching ignores case and accepts _ as a subtag separator.
+func ResolveLocale(requested string, available []string) string {
+	tag := strings.ToLower(strings.ReplaceAll(strings.TrimSpace(requested), "_", "-"))
+	for tag != "" {
+		for _, code := range available {
+			if strings.EqualFold(code, tag) {
+				return code
+			}
+		}
+		i := strings.LastIndexByte(tag, '-')
+		if i < 0 {
+			break
+		}
+		tag = tag[:i]
+	}
+	return defaultLocale
+}
+
+// HelloLocale returns a greeting in the given locale, resolved with
+// ResolveLocale so that regional tags such as es-MX use their base language
+func HelloLocale(name, locale string) string {
+	return HelloLocaleStyled(name, locale, Exclaim)
+}
+
+// HelloLocaleStyled is like HelloLocale with the closing punctuation chosen
+// by style. Under Exclaim, languages that open exclamations, such as
+// Spanish, keep their leading mark.
+func HelloLocaleStyled(name, locale string, style PunctuationStyle) string {
+	return locales[lookupLocale(locale)].greet(name, style)
+}
+
+// SupportedLocales returns the supported locale codes in sorted order
+func SupportedLocales() []string {
+	codes := make([]string, 0, len(locales))
+	for code := range locales {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
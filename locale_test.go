@@ -0,0 +1,95 @@
+package main
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestHelloLocale(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		locale   string
+		expected string
+	}{
+		{"english", "Ana", "en", "Hello, Ana!"},
+		{"english empty", "", "en", "Hello, World!"},
+		{"spanish", "Ana", "es", "¡Hola, Ana!"},
+		{"spanish empty", "", "es", "¡Hola, Mundo!"},
+		{"french", "Ana", "fr", "Bonjour, Ana!"},
+		{"french empty", "", "fr", "Bonjour, le monde!"},
+		{"german", "Ana", "de", "Hallo, Ana!"},
+		{"german empty", "", "de", "Hallo, Welt!"},
+		{"japanese", "Ana", "ja", "こんにちは、Anaさん！"},
+		{"japanese empty", "", "ja", "こんにちは、世界！"},
+		{"upper case code", "Ana", "ES", "¡Hola, Ana!"},
+		{"unknown locale", "Ana", "xx", "Hello, Ana!"},
+		{"unknown locale empty", "", "xx", "Hello, World!"},
+		{"empty locale", "Ana", "", "Hello, Ana!"},
+		{"region subtag", "Ana", "es-MX", "¡Hola, Ana!"},
+		{"region subtag empty name", "", "de-AT", "Hallo, Welt!"},
+		{"unknown base with region", "Ana", "pt-BR", "Hello, Ana!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := HelloLocale(tt.input, tt.locale)
+			if result != tt.expected {
+				t.Errorf("HelloLocale(%q, %q) = %q, want %q", tt.input, tt.locale, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHelloLocaleMatchesHello(t *testing.T) {
+	for _, name := range []string{"", "Gopher"} {
+		if got, want := HelloLocale(name, defaultLocale), Hello(name); got != want {
+			t.Errorf("HelloLocale(%q, %q) = %q, want %q", name, defaultLocale, got, want)
+		}
+	}
+}
+
+func TestSupportedLocales(t *testing.T) {
+	expected := []string{"de", "en", "es", "fr", "ja"}
+	if result := SupportedLocales(); !slices.Equal(result, expected) {
+		t.Errorf("SupportedLocales() = %q, want %q", result, expected)
+	}
+}
+
+func TestResolveLocale(t *testing.T) {
+	available := []string{"en", "es", "fr", "zh-Hant"}
+
+	tests := []struct {
+		name      string
+		requested string
+		expected  string
+	}{
+		{"exact", "fr", "fr"},
+		{"region falls back to base", "es-MX", "es"},
+		{"underscore separator", "es_MX", "es"},
+		{"case insensitive", "ES-mx", "es"},
+		{"unknown region subtag", "fr-XX", "fr"},
+		{"script and region", "zh-Hant-TW", "zh-Hant"},
+		{"script only base missing", "zh-Hans", "en"},
+		{"unknown base", "pt-BR", "en"},
+		{"unknown language", "pt", "en"},
+		{"empty", "", "en"},
+		{"whitespace", "  es-MX ", "es"},
+		{"dangling separator", "es-", "es"},
+		{"separator only", "-", "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := ResolveLocale(tt.requested, available); result != tt.expected {
+				t.Errorf("ResolveLocale(%q) = %q, want %q", tt.requested, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestResolveLocaleNoneAvailable(t *testing.T) {
+	if result := ResolveLocale("es-MX", nil); result != defaultLocale {
+		t.Errorf("ResolveLocale with no locales = %q, want %q", result, defaultLocale)
+	}
+}
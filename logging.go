@@ -0,0 +1,31 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// ErrUnknownLogLevel is returned for an unsupported log level
+var ErrUnknownLogLevel = errors.New("unknown log level")
+
+// SetupLogger returns a text logger writing records at or above level to w.
+// Level is one of debug, info, warn or error.
+func SetupLogger(level string, w io.Writer) (*slog.Logger, error) {
+	var l slog.Level
+	switch strings.ToLower(level) {
+	case "debug":
+		l = slog.LevelDebug
+	case "info":
+		l = slog.LevelInfo
+	case "warn":
+		l = slog.LevelWarn
+	case "error":
+		l = slog.LevelError
+	default:
+		return nil, fmt.Errorf("%w %q (want debug, info, warn or error)", ErrUnknownLogLevel, level)
+	}
+	return slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: l})), nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSetupLoggerLevels(t *testing.T) {
+	tests := []struct {
+		level     string
+		wantDebug bool
+		wantInfo  bool
+		wantWarn  bool
+	}{
+		{"debug", true, true, true},
+		{"info", false, true, true},
+		{"warn", false, false, true},
+		{"error", false, false, false},
+		{"INFO", false, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.level, func(t *testing.T) {
+			var buf bytes.Buffer
+			logger, err := SetupLogger(tt.level, &buf)
+			if err != nil {
+				t.Fatalf("SetupLogger(%q) error: %v", tt.level, err)
+			}
+			logger.Debug("debug record")
+			logger.Info("info record")
+			logger.Warn("warn record")
+
+			out := buf.String()
+			for msg, want := range map[string]bool{"debug record": tt.wantDebug, "info record": tt.wantInfo, "warn record": tt.wantWarn} {
+				if got := strings.Contains(out, msg); got != want {
+					t.Errorf("level %s: output contains %q = %v, want %v\noutput: %s", tt.level, msg, got, want, out)
+				}
+			}
+		})
+	}
+}
+
+func TestSetupLoggerUnknownLevel(t *testing.T) {
+	if _, err := SetupLogger("verbose", &bytes.Buffer{}); !errors.Is(err, ErrUnknownLogLevel) {
+		t.Errorf("SetupLogger with unknown level error = %v, want ErrUnknownLogLevel", err)
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import "container/list"
+
+// lru maps keys to values and tracks the order they were last used in, so
+// the least recently used can be evicted. It is not safe for concurrent
+// use; the types built on it guard it with their own mutex.
+type lru[K comparable, V any] struct {
+	entries map[K]*list.Element
+	order   *list.List // front is most recently used
+}
+
+// lruEntry is one key and its value
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// newLRU returns an empty lru
+func newLRU[K comparable, V any]() *lru[K, V] {
+	return &lru[K, V]{entries: make(map[K]*list.Element), order: list.New()}
+}
+
+// get returns the value for key and marks it most recently used
+func (c *lru[K, V]) get(key K) (V, bool) {
+	el, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*lruEntry[K, V]).value, true
+}
+
+// peek returns the value for key without marking it used
+func (c *lru[K, V]) peek(key K) (V, bool) {
+	el, ok := c.entries[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return el.Value.(*lruEntry[K, V]).value, true
+}
+
+// put sets the value for key and marks it most recently used, then evicts
+// the least recently used keys beyond max; max zero or less is unbounded
+func (c *lru[K, V]) put(key K, value V, max int) {
+	if el, ok := c.entries[key]; ok {
+		el.Value.(*lruEntry[K, V]).value = value
+		c.order.MoveToFront(el)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&lruEntry[K, V]{key: key, value: value})
+	for max > 0 && c.order.Len() > max {
+		c.remove(c.order.Back().Value.(*lruEntry[K, V]).key)
+	}
+}
+
+// remove deletes key, if present
+func (c *lru[K, V]) remove(key K) {
+	if el, ok := c.entries[key]; ok {
+		c.order.Remove(el)
+		delete(c.entries, key)
+	}
+}
+
+// len returns the number of keys held
+func (c *lru[K, V]) len() int {
+	return c.order.Len()
+}
@@ -0,0 +1,38 @@
+package main
+
+import "testing"
+
+func TestLRU(t *testing.T) {
+	c := newLRU[string, int]()
+	c.put("a", 1, 2)
+	c.put("b", 2, 2)
+	c.get("a")        // a is now most recently used
+	c.peek("b")       // peeking leaves b least recently used
+	c.put("c", 3, 2)  // evicts b
+	c.put("a", 10, 2) // updates a in place
+
+	if n := c.len(); n != 2 {
+		t.Errorf("len() = %d, want 2", n)
+	}
+	if _, ok := c.get("b"); ok {
+		t.Error("b was not evicted")
+	}
+	if v, ok := c.get("a"); !ok || v != 10 {
+		t.Errorf("get(a) = %d, %t, want 10, true", v, ok)
+	}
+
+	c.remove("c")
+	if _, ok := c.peek("c"); ok || c.len() != 1 {
+		t.Errorf("after remove(c), peek(c) = %t and len() = %d, want false and 1", ok, c.len())
+	}
+}
+
+func TestLRUUnbounded(t *testing.T) {
+	c := newLRU[int, int]()
+	for i := range 100 {
+		c.put(i, i, 0)
+	}
+	if n := c.len(); n != 100 {
+		t.Errorf("len() = %d, want 100 with no limit", n)
+	}
+}
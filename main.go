@@ -1,9 +1,47 @@
 package main
 
-import "fmt"
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// binaryName is the command name shown in usage output
+const binaryName = "codex-universal"
 
 func main() {
-	fmt.Println("Codex Universal - Multi-language development environment")
+	stdout := &bufferedFile{Writer: bufio.NewWriter(os.Stdout), file: os.Stdout}
+	code := Run(os.Args[1:], stdout, os.Stderr)
+	if err := stdout.Flush(); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		if code == ExitOK {
+			code = ExitRuntime
+		}
+	}
+	os.Exit(code)
+}
+
+// bufferedFile buffers writes to a file while still exposing its
+// descriptor, so terminal detection sees through the buffer
+type bufferedFile struct {
+	*bufio.Writer
+	file fdWriter
+}
+
+// Fd returns the descriptor of the underlying file
+func (b *bufferedFile) Fd() uintptr {
+	return b.file.Fd()
+}
+
+// Unbuffered flushes b and returns the file beneath it, for output whose
+// writes must reach the file before they are counted as done
+func (b *bufferedFile) Unbuffered() (io.Writer, error) {
+	if err := b.Flush(); err != nil {
+		return nil, err
+	}
+	return b.file, nil
 }
 
 // Hello returns a greeting message
@@ -12,4 +50,38 @@ func Hello(name string) string {
 		return "Hello, World!"
 	}
 	return fmt.Sprintf("Hello, %s!", name)
-}
\ No newline at end of file
+}
+
+// HelloInto writes the greeting for name to buf. It grows buf at most once,
+// so greeting many names costs one allocation per greeting.
+func HelloInto(buf *strings.Builder, name string) {
+	if name == "" {
+		name = "World"
+	}
+	buf.Grow(len("Hello, ") + len(name) + len("!"))
+	buf.WriteString("Hello, ")
+	buf.WriteString(name)
+	buf.WriteByte('!')
+}
+
+// HelloAll returns one greeting per name, in order
+func HelloAll(names []string) []string {
+	greetings := make([]string, 0, len(names))
+	var buf strings.Builder
+	for _, name := range names {
+		buf.Reset()
+		HelloInto(&buf, name)
+		greetings = append(greetings, buf.String())
+	}
+	return greetings
+}
+
+// HelloRepeat returns the greeting for name n times. A zero or negative n
+// yields an empty slice.
+func HelloRepeat(name string, n int) []string {
+	greetings := make([]string, 0, max(n, 0))
+	for range n {
+		greetings = append(greetings, Hello(name))
+	}
+	return greetings
+}
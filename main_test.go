@@ -1,6 +1,12 @@
 package main
 
-import "testing"
+import (
+	"bufio"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
 
 func TestHello(t *testing.T) {
 	tests := []struct {
@@ -20,4 +26,156 @@ func TestHello(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestHelloAll(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []string
+		expected []string
+	}{
+		{"nil slice", nil, []string{}},
+		{"empty slice", []string{}, []string{}},
+		{"single name", []string{"Gopher"}, []string{"Hello, Gopher!"}},
+		{"mixed names", []string{"Alice", "", "Bob"}, []string{"Hello, Alice!", "Hello, World!", "Hello, Bob!"}},
+		{"all empty", []string{"", ""}, []string{"Hello, World!", "Hello, World!"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := HelloAll(tt.input)
+			if result == nil {
+				t.Fatalf("HelloAll(%q) = nil, want non-nil slice", tt.input)
+			}
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("HelloAll(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHelloRepeat(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		n        int
+		expected []string
+	}{
+		{"zero", "Alice", 0, []string{}},
+		{"one", "Alice", 1, []string{"Hello, Alice!"}},
+		{"several", "Alice", 3, []string{"Hello, Alice!", "Hello, Alice!", "Hello, Alice!"}},
+		{"several empty name", "", 2, []string{"Hello, World!", "Hello, World!"}},
+		{"negative", "Alice", -2, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := HelloRepeat(tt.input, tt.n)
+			if result == nil {
+				t.Fatalf("HelloRepeat(%q, %d) = nil, want non-nil slice", tt.input, tt.n)
+			}
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("HelloRepeat(%q, %d) = %q, want %q", tt.input, tt.n, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHelloInto(t *testing.T) {
+	for _, name := range []string{"", "Gopher", "José"} {
+		var buf strings.Builder
+		HelloInto(&buf, name)
+		if buf.String() != Hello(name) {
+			t.Errorf("HelloInto(%q) wrote %q, want %q", name, buf.String(), Hello(name))
+		}
+	}
+
+	var buf strings.Builder
+	buf.WriteString("> ")
+	HelloInto(&buf, "Gopher")
+	if buf.String() != "> Hello, Gopher!" {
+		t.Errorf("HelloInto appended %q, want %q", buf.String(), "> Hello, Gopher!")
+	}
+}
+
+// TestHelloAllocs documents allocation counts: Hello allocates twice per
+// call (boxing the name for fmt.Sprintf and the result), HelloInto once for
+// the builder's buffer, and HelloAll once per name plus once for the slice.
+func TestHelloAllocs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping allocation counts in short mode")
+	}
+
+	if allocs := testing.AllocsPerRun(100, func() { _ = Hello("Gopher") }); allocs > 2 {
+		t.Errorf("Hello allocs = %v, want <= 2", allocs)
+	}
+
+	intoAllocs := testing.AllocsPerRun(100, func() {
+		var buf strings.Builder
+		HelloInto(&buf, "Gopher")
+		_ = buf.String()
+	})
+	if intoAllocs != 1 {
+		t.Errorf("HelloInto allocs = %v, want 1", intoAllocs)
+	}
+
+	names := []string{"Alice", "", "Bob", "Carol"}
+	allAllocs := testing.AllocsPerRun(100, func() { _ = HelloAll(names) })
+	if want := float64(len(names) + 1); allAllocs != want {
+		t.Errorf("HelloAll(%d names) allocs = %v, want %v", len(names), allAllocs, want)
+	}
+}
+
+// benchNames returns n names for the bulk greeting benchmarks
+func benchNames(n int) []string {
+	names := make([]string, n)
+	for i := range names {
+		if i%10 != 0 {
+			names[i] = "Gopher"
+		}
+	}
+	return names
+}
+
+func BenchmarkHelloLoop(b *testing.B) {
+	names := benchNames(10000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		greetings := make([]string, 0, len(names))
+		for _, name := range names {
+			greetings = append(greetings, Hello(name))
+		}
+	}
+}
+
+func BenchmarkHelloAll(b *testing.B) {
+	names := benchNames(10000)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = HelloAll(names)
+	}
+}
+
+// fdLimitWriter is a limitWriter posing as a file that is not a terminal
+type fdLimitWriter struct {
+	*limitWriter
+}
+
+func (fdLimitWriter) Fd() uintptr {
+	return ^uintptr(0)
+}
+
+func TestRunStdinBufferedWriteError(t *testing.T) {
+	const greeting = "Hello, Gopher!\n"
+	w := fdLimitWriter{&limitWriter{n: 10 * len(greeting), err: errors.New("broken pipe")}}
+	stdout := &bufferedFile{Writer: bufio.NewWriter(w), file: w}
+	var stderr strings.Builder
+
+	code := run([]string{"-stdin"}, strings.NewReader(strings.Repeat("Gopher\n", 1000)), stdout, &stderr)
+	if code != ExitRuntime {
+		t.Errorf("exit code = %d, want %d", code, ExitRuntime)
+	}
+	if want := "failed after 10 lines: broken pipe"; !strings.Contains(stderr.String(), want) {
+		t.Errorf("stderr = %q, want it to contain %q", stderr.String(), want)
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// MetricsRecorder records greeting metrics for the server
+type MetricsRecorder interface {
+	// IncGreetings counts one greeting served in locale
+	IncGreetings(locale string)
+	// WritePrometheus writes the metrics in Prometheus text format
+	WritePrometheus(w io.Writer) error
+}
+
+// StatsReporter is implemented by MetricsRecorders that can report the
+// greeting counts they hold
+type StatsReporter interface {
+	Stats() GreetingStats
+}
+
+// GreetingStats counts the greetings served, in total and by locale
+type GreetingStats struct {
+	Total    uint64
+	ByLocale map[string]uint64
+}
+
+// Metrics is an in-memory MetricsRecorder and StatsReporter safe for
+// concurrent use
+type Metrics struct {
+	mu    sync.Mutex
+	stats GreetingStats
+}
+
+// NewMetrics returns an empty Metrics
+func NewMetrics() *Metrics {
+	return &Metrics{stats: GreetingStats{ByLocale: make(map[string]uint64)}}
+}
+
+// IncGreetings counts one greeting served in locale
+func (m *Metrics) IncGreetings(locale string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.stats.Total++
+	m.stats.ByLocale[locale]++
+}
+
+// Stats returns a copy of the greeting counts
+func (m *Metrics) Stats() GreetingStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return GreetingStats{Total: m.stats.Total, ByLocale: maps.Clone(m.stats.ByLocale)}
+}
+
+// Total returns the number of greetings served
+func (m *Metrics) Total() uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.stats.Total
+}
+
+// LocaleCount returns the number of greetings served in locale
+func (m *Metrics) LocaleCount(locale string) uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.stats.ByLocale[locale]
+}
+
+// WritePrometheus writes greetings_total and greetings_by_locale in
+// Prometheus text exposition format, with locales in sorted order
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	stats := m.Stats()
+	locales := slices.Sorted(maps.Keys(stats.ByLocale))
+
+	if _, err := fmt.Fprintf(w, "# HELP greetings_total Total number of greetings served.\n# TYPE greetings_total counter\ngreetings_total %d\n", stats.Total); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprint(w, "# HELP greetings_by_locale Number of greetings served by locale.\n# TYPE greetings_by_locale counter\n"); err != nil {
+		return err
+	}
+	for _, locale := range locales {
+		if _, err := fmt.Fprintf(w, "greetings_by_locale{locale=%q} %d\n", locale, stats.ByLocale[locale]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeMetrics records calls to IncGreetings for assertions
+type fakeMetrics struct {
+	mu      sync.Mutex
+	locales []string
+}
+
+func (f *fakeMetrics) IncGreetings(locale string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.locales = append(f.locales, locale)
+}
+
+func (f *fakeMetrics) WritePrometheus(w io.Writer) error {
+	_, err := io.WriteString(w, "fake\n")
+	return err
+}
+
+func TestServerRecordsGreetings(t *testing.T) {
+	fake := &fakeMetrics{}
+	srv := newTestServer()
+	srv.Metrics = fake
+	handler := srv.Handler()
+
+	for _, target := range []string{"/hello", "/hello?name=Ana&locale=es-MX", "/hello?name=Bob&locale=xx"} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET %s status = %d, want %d", target, rec.Code, http.StatusOK)
+		}
+	}
+
+	expected := []string{"en", "es", "en"}
+	if strings.Join(fake.locales, ",") != strings.Join(expected, ",") {
+		t.Errorf("recorded locales = %q, want %q", fake.locales, expected)
+	}
+}
+
+func TestMetricsEndpoint(t *testing.T) {
+	srv := newTestServer()
+	handler := srv.Handler()
+
+	for _, target := range []string{"/hello", "/hello?name=Ana", "/hello?name=Ana&locale=es"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /metrics status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{
+		"# TYPE greetings_total counter\n",
+		"greetings_total 3\n",
+		"# TYPE greetings_by_locale counter\n",
+		`greetings_by_locale{locale="en"} 2` + "\n",
+		`greetings_by_locale{locale="es"} 1` + "\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("/metrics body does not contain %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestMetricsConcurrent(t *testing.T) {
+	const workers, perWorker = 8, 100
+	m := NewMetrics()
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(locale string) {
+			defer wg.Done()
+			for j := 0; j < perWorker; j++ {
+				m.IncGreetings(locale)
+			}
+		}([]string{"en", "es"}[i%2])
+	}
+	wg.Wait()
+
+	if got := m.Total(); got != workers*perWorker {
+		t.Errorf("Total() = %d, want %d", got, workers*perWorker)
+	}
+	if got := m.LocaleCount("es"); got != workers/2*perWorker {
+		t.Errorf("LocaleCount(es) = %d, want %d", got, workers/2*perWorker)
+	}
+}
+
+func TestMetricsWritePrometheusEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewMetrics().WritePrometheus(&buf); err != nil {
+		t.Fatalf("WritePrometheus error: %v", err)
+	}
+	if !strings.Contains(buf.String(), "greetings_total 0\n") {
+		t.Errorf("WritePrometheus output = %q, want greetings_total 0", buf.String())
+	}
+}
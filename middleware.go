@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+)
+
+// Middleware wraps an http.Handler with extra behavior
+type Middleware func(http.Handler) http.Handler
+
+// Chain composes mw into a single Middleware. The first middleware is the
+// outermost, so it sees each request first and its response last.
+func Chain(mw ...Middleware) Middleware {
+	return func(next http.Handler) http.Handler {
+		for i := len(mw) - 1; i >= 0; i-- {
+			next = mw[i](next)
+		}
+		return next
+	}
+}
+
+// requestIDHeader carries the request ID in requests and responses
+const requestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength bounds the client-supplied request IDs that are
+// echoed back rather than replaced
+const maxRequestIDLength = 128
+
+// requestIDKey is the context key for the request ID
+type requestIDKey struct{}
+
+// RequestID returns the request ID stored in ctx by the request-ID
+// middleware, or "" if there is none
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// requestID echoes the request's X-Request-ID header in the response,
+// generating one if it is absent or malformed, and stores it in the request
+// context for later handlers
+func requestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(requestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		w.Header().Set(requestIDHeader, id)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), requestIDKey{}, id)))
+	})
+}
+
+// validRequestID reports whether id is a non-empty, reasonably short run of
+// printable ASCII that is safe to echo in a header
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID returns a random 128-bit request ID in hex
+func newRequestID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// logRequest logs each request handled by next with its name parameter,
+// request ID and response time
+func (s *Server) logRequest(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		next.ServeHTTP(w, r)
+		s.Logger.Info("request",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"name", r.URL.Query().Get("name"),
+			"request_id", RequestID(r.Context()),
+			"duration", time.Since(start),
+		)
+	})
+}
+
+// recoverPanic turns a panic in next into a 500 Internal Server Error
+// response, logging the panic value instead of crashing the server.
+// http.ErrAbortHandler is re-raised so net/http can abort the response.
+func (s *Server) recoverPanic(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			v := recover()
+			if v == nil {
+				return
+			}
+			if v == http.ErrAbortHandler {
+				panic(v)
+			}
+			s.Logger.Error("handler panicked",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"request_id", RequestID(r.Context()),
+				"panic", v,
+			)
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestChainOrder(t *testing.T) {
+	var order []string
+	tag := func(name string) Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				order = append(order, name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+	h := Chain(tag("first"), tag("second"), tag("third"))(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		order = append(order, "handler")
+	}))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if got, want := strings.Join(order, ","), "first,second,third,handler"; got != want {
+		t.Errorf("call order = %s, want %s", got, want)
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name     string
+		incoming string
+		echoed   bool
+	}{
+		{"passthrough", "abc-123", true},
+		{"absent", "", false},
+		{"malformed", "has space", false},
+		{"too long", strings.Repeat("x", maxRequestIDLength+1), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var seen string
+			h := requestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				seen = RequestID(r.Context())
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.incoming != "" {
+				req.Header.Set(requestIDHeader, tt.incoming)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			got := rec.Header().Get(requestIDHeader)
+			if got == "" {
+				t.Fatal("response has no X-Request-ID header")
+			}
+			if got != seen {
+				t.Errorf("context request ID = %q, header = %q, want them equal", seen, got)
+			}
+			if tt.echoed && got != tt.incoming {
+				t.Errorf("X-Request-ID = %q, want %q echoed", got, tt.incoming)
+			}
+			if !tt.echoed && (got == tt.incoming || len(got) != 32) {
+				t.Errorf("X-Request-ID = %q, want a generated 32-character ID", got)
+			}
+		})
+	}
+}
+
+func TestRecoverPanic(t *testing.T) {
+	var buf bytes.Buffer
+	srv := NewServer("")
+	srv.Logger = slog.New(slog.NewTextHandler(&buf, nil))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/panic", func(http.ResponseWriter, *http.Request) { panic("boom") })
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("ok")) })
+	ts := httptest.NewServer(Chain(requestID, srv.recoverPanic)(mux))
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/panic")
+	if err != nil {
+		t.Fatalf("GET /panic: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("GET /panic status = %d, want %d", resp.StatusCode, http.StatusInternalServerError)
+	}
+	if !strings.Contains(buf.String(), "panic=boom") {
+		t.Errorf("log output %q does not record the panic", buf.String())
+	}
+
+	resp, err = http.Get(ts.URL + "/ok")
+	if err != nil {
+		t.Fatalf("GET /ok after panic: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("GET /ok after panic status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+}
+
+func TestServerHandlerRequestID(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/hello?name=Gopher", nil)
+	req.Header.Set(requestIDHeader, "trace-42")
+	rec := httptest.NewRecorder()
+	newTestServer().Handler().ServeHTTP(rec, req)
+
+	if got := rec.Header().Get(requestIDHeader); got != "trace-42" {
+		t.Errorf("X-Request-ID = %q, want %q", got, "trace-42")
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ErrUnterminatedQuote is returned by ParseNames for a quote left open
+var ErrUnterminatedQuote = errors.New("unterminated quote")
+
+// ParseNames splits raw into names on commas, except for commas inside
+// double quotes, so `"Smith, John",Alice` yields two names. Within quotes
+// a doubled quote stands for a literal one. Whitespace around each name is
+// trimmed unless quoted. An empty raw string has no names; empty fields
+// such as the middle of "a,,b" are empty names.
+func ParseNames(raw string) ([]string, error) {
+	names := []string{}
+	if raw == "" {
+		return names, nil
+	}
+
+	var field []rune
+	var quoted []bool // whether each rune of field was quoted
+	inQuotes, opened := false, 0
+	end := func() {
+		lo, hi := 0, len(field)
+		for lo < hi && !quoted[lo] && unicode.IsSpace(field[lo]) {
+			lo++
+		}
+		for hi > lo && !quoted[hi-1] && unicode.IsSpace(field[hi-1]) {
+			hi--
+		}
+		names = append(names, string(field[lo:hi]))
+		field, quoted = field[:0], quoted[:0]
+	}
+
+	runes := []rune(raw)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '"' && inQuotes && i+1 < len(runes) && runes[i+1] == '"':
+			field, quoted = append(field, '"'), append(quoted, true)
+			i++
+		case r == '"':
+			inQuotes = !inQuotes
+			opened = i
+		case r == ',' && !inQuotes:
+			end()
+		default:
+			field, quoted = append(field, r), append(quoted, inQuotes)
+		}
+	}
+	if inQuotes {
+		return nil, fmt.Errorf("parse names: %w opened at character %d", ErrUnterminatedQuote, opened+1)
+	}
+	end()
+	return names, nil
+}
+
+// FormatNames joins names into a string that ParseNames splits back into
+// the same names, quoting each one
+func FormatNames(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+	}
+	return strings.Join(quoted, ",")
+}
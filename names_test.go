@@ -0,0 +1,103 @@
+package main
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestParseNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []string
+	}{
+		{"empty", "", []string{}},
+		{"single", "Alice", []string{"Alice"}},
+		{"comma separated", "Alice,Bob", []string{"Alice", "Bob"}},
+		{"trims spaces", " Alice , Bob ", []string{"Alice", "Bob"}},
+		{"quoted comma", `"Smith, John",Alice`, []string{"Smith, John", "Alice"}},
+		{"quoted keeps spaces", `" Alice "`, []string{" Alice "}},
+		{"spaces around quotes", `  "Smith, John"  , Alice`, []string{"Smith, John", "Alice"}},
+		{"escaped quote", `"Dwayne ""The Rock"" Johnson"`, []string{`Dwayne "The Rock" Johnson`}},
+		{"quote inside word", `Mary" Jane"`, []string{"Mary Jane"}},
+		{"empty quoted", `""`, []string{""}},
+		{"empty fields", "a,,b", []string{"a", "", "b"}},
+		{"trailing comma", "Alice,", []string{"Alice", ""}},
+		{"multibyte", `José,"山田, 太郎"`, []string{"José", "山田, 太郎"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseNames(tt.input)
+			if err != nil {
+				t.Fatalf("ParseNames(%q) error: %v", tt.input, err)
+			}
+			if !slices.Equal(result, tt.expected) {
+				t.Errorf("ParseNames(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseNamesMalformed(t *testing.T) {
+	tests := []string{
+		`"Smith, John`,
+		`Alice,"Bob`,
+		`"a""`,
+		`"`,
+	}
+
+	for _, input := range tests {
+		t.Run(input, func(t *testing.T) {
+			result, err := ParseNames(input)
+			if !errors.Is(err, ErrUnterminatedQuote) {
+				t.Errorf("ParseNames(%q) = %q, %v, want ErrUnterminatedQuote", input, result, err)
+			}
+		})
+	}
+}
+
+func TestFormatNames(t *testing.T) {
+	names := []string{"Smith, John", `Dwayne "The Rock"`, " spaced ", ""}
+	raw := FormatNames(names)
+	if want := `"Smith, John","Dwayne ""The Rock"""," spaced ",""`; raw != want {
+		t.Errorf("FormatNames = %q, want %q", raw, want)
+	}
+	result, err := ParseNames(raw)
+	if err != nil || !slices.Equal(result, names) {
+		t.Errorf("ParseNames(FormatNames(%q)) = %q, %v, want the names back", names, result, err)
+	}
+}
+
+func FuzzParseNames(f *testing.F) {
+	for _, seed := range []string{
+		"",
+		"Alice",
+		"Alice,Bob",
+		`"Smith, John",Alice`,
+		`"Dwayne ""The Rock"" Johnson"`,
+		`"unterminated`,
+		` a , "b" ,, `,
+		"José,\"山田, 太郎\"",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, raw string) {
+		names, err := ParseNames(raw)
+		if err != nil {
+			if !errors.Is(err, ErrUnterminatedQuote) {
+				t.Fatalf("ParseNames(%q) error %v is not ErrUnterminatedQuote", raw, err)
+			}
+			return
+		}
+		again, err := ParseNames(FormatNames(names))
+		if err != nil {
+			t.Fatalf("ParseNames(FormatNames(%q)) error: %v", names, err)
+		}
+		if !slices.Equal(again, names) {
+			t.Fatalf("round trip of %q = %q, want %q", raw, again, names)
+		}
+	})
+}
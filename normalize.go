@@ -0,0 +1,21 @@
+package main
+
+import (
+	"strings"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+)
+
+// NormalizeName trims surrounding whitespace from name and title-cases each
+// word using Unicode case mapping, so "  josé ALVAREZ " becomes
+// "José Alvarez". Characters without case pass through unchanged.
+func NormalizeName(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return name
+	}
+	// A Caser keeps state between calls, so one is made per name rather
+	// than shared between goroutines.
+	return cases.Title(language.Und).String(name)
+}
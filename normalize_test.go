@@ -0,0 +1,41 @@
+package main
+
+import "testing"
+
+func TestNormalizeName(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"lower case", "alice", "Alice"},
+		{"upper case", "ALICE", "Alice"},
+		{"mixed case", "aLiCe", "Alice"},
+		{"accented", "josé", "José"},
+		{"accented upper", "JOSÉ", "José"},
+		{"accented initial", "élodie", "Élodie"},
+		{"multiple words", "mary jane", "Mary Jane"},
+		{"surrounding whitespace", "  \tbob \n", "Bob"},
+		{"german sharp s", "jürgen strauß", "Jürgen Strauß"},
+		{"greek final sigma", "ΟΔΥΣΣΕΥΣ", "Οδυσσευς"},
+		{"digits", "1234", "1234"},
+		{"punctuation", "-_-", "-_-"},
+		{"uncased script", "さくら", "さくら"},
+		{"empty", "", ""},
+		{"whitespace only", "   ", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := NormalizeName(tt.input); result != tt.expected {
+				t.Errorf("NormalizeName(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNormalizeNameConsistent(t *testing.T) {
+	if a, b := NormalizeName("ALICE"), NormalizeName("alice"); a != b {
+		t.Errorf("NormalizeName(%q) = %q, NormalizeName(%q) = %q, want equal", "ALICE", a, "alice", b)
+	}
+}
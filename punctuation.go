@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PunctuationStyle selects how HelloStyled ends a greeting
+type PunctuationStyle int
+
+// Punctuation styles
+const (
+	Exclaim PunctuationStyle = iota // Hello, Alice!
+	Period                          // Hello, Alice.
+	None                            // Hello, Alice
+)
+
+// PunctuationStyles returns every punctuation style in declaration order
+func PunctuationStyles() []PunctuationStyle {
+	return []PunctuationStyle{Exclaim, Period, None}
+}
+
+// String returns the style name in lower case
+func (p PunctuationStyle) String() string {
+	switch p {
+	case Exclaim:
+		return "exclaim"
+	case Period:
+		return "period"
+	case None:
+		return "none"
+	}
+	return fmt.Sprintf("PunctuationStyle(%d)", int(p))
+}
+
+// ParsePunctuationStyle returns the style named s, ignoring case
+func ParsePunctuationStyle(s string) (PunctuationStyle, error) {
+	for _, p := range PunctuationStyles() {
+		if strings.EqualFold(s, p.String()) {
+			return p, nil
+		}
+	}
+	return Exclaim, fmt.Errorf("unknown punctuation style %q (want exclaim, period or none)", s)
+}
+
+// HelloStyled returns the English greeting for name ended in style. Unknown
+// styles fall back to Exclaim.
+func HelloStyled(name string, style PunctuationStyle) string {
+	return HelloLocaleStyled(name, defaultLocale, style)
+}
+
+// StyledGreeter is a LocaleGreeter that ends greetings in Style
+type StyledGreeter struct {
+	Style PunctuationStyle
+}
+
+// Greet returns HelloStyled(name, g.Style)
+func (g StyledGreeter) Greet(name string) string {
+	return HelloStyled(name, g.Style)
+}
+
+// GreetLocale returns HelloLocaleStyled(name, locale, g.Style)
+func (g StyledGreeter) GreetLocale(name, locale string) string {
+	return HelloLocaleStyled(name, locale, g.Style)
+}
@@ -0,0 +1,87 @@
+package main
+
+import "testing"
+
+func TestHelloStyled(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		style    PunctuationStyle
+		expected string
+	}{
+		{"exclaim", "Alice", Exclaim, "Hello, Alice!"},
+		{"period", "Alice", Period, "Hello, Alice."},
+		{"none", "Alice", None, "Hello, Alice"},
+		{"exclaim empty", "", Exclaim, "Hello, World!"},
+		{"period empty", "", Period, "Hello, World."},
+		{"none empty", "", None, "Hello, World"},
+		{"unknown style", "Alice", PunctuationStyle(42), "Hello, Alice!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := HelloStyled(tt.input, tt.style); result != tt.expected {
+				t.Errorf("HelloStyled(%q, %v) = %q, want %q", tt.input, tt.style, result, tt.expected)
+			}
+		})
+	}
+
+	for _, name := range []string{"", "Alice"} {
+		if got, want := HelloStyled(name, Exclaim), Hello(name); got != want {
+			t.Errorf("HelloStyled(%q, Exclaim) = %q, want Hello's %q", name, got, want)
+		}
+	}
+}
+
+func TestHelloLocaleStyled(t *testing.T) {
+	tests := []struct {
+		locale   string
+		input    string
+		style    PunctuationStyle
+		expected string
+	}{
+		{"es", "Ana", Exclaim, "¡Hola, Ana!"},
+		{"es", "Ana", Period, "Hola, Ana."},
+		{"es", "Ana", None, "Hola, Ana"},
+		{"es", "", Exclaim, "¡Hola, Mundo!"},
+		{"es", "", Period, "Hola, Mundo."},
+		{"fr", "Luc", Period, "Bonjour, Luc."},
+		{"de", "", None, "Hallo, Welt"},
+		{"ja", "田中", Exclaim, "こんにちは、田中さん！"},
+		{"ja", "田中", Period, "こんにちは、田中さん。"},
+		{"ja", "", None, "こんにちは、世界"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale+" "+tt.style.String(), func(t *testing.T) {
+			if result := HelloLocaleStyled(tt.input, tt.locale, tt.style); result != tt.expected {
+				t.Errorf("HelloLocaleStyled(%q, %q, %v) = %q, want %q", tt.input, tt.locale, tt.style, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParsePunctuationStyle(t *testing.T) {
+	for _, p := range PunctuationStyles() {
+		got, err := ParsePunctuationStyle(p.String())
+		if err != nil || got != p {
+			t.Errorf("ParsePunctuationStyle(%q) = %v, %v, want %v", p.String(), got, err, p)
+		}
+	}
+	if got, err := ParsePunctuationStyle("PERIOD"); err != nil || got != Period {
+		t.Errorf("ParsePunctuationStyle(PERIOD) = %v, %v, want period", got, err)
+	}
+	if _, err := ParsePunctuationStyle("question"); err == nil {
+		t.Error("ParsePunctuationStyle(question) = nil error, want error")
+	}
+}
+
+func TestStyledGreeter(t *testing.T) {
+	var g LocaleGreeter = StyledGreeter{Style: Period}
+	if result := g.Greet("Alice"); result != "Hello, Alice." {
+		t.Errorf("Greet = %q, want %q", result, "Hello, Alice.")
+	}
+	if result := g.GreetLocale("Ana", "es"); result != "Hola, Ana." {
+		t.Errorf("GreetLocale = %q, want %q", result, "Hola, Ana.")
+	}
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+)
+
+// randomPhrasings are the salutations HelloRandom chooses between
+var randomPhrasings = []string{"Hi", "Hello", "Hey", "Greetings"}
+
+// HelloRandom returns the greeting for name using a salutation chosen by
+// rng, so a seeded rng yields a repeatable sequence. A nil rng uses the
+// shared top-level source.
+func HelloRandom(name string, rng *rand.Rand) string {
+	if name == "" {
+		name = "World"
+	}
+	var i int
+	if rng != nil {
+		i = rng.Intn(len(randomPhrasings))
+	} else {
+		i = rand.Intn(len(randomPhrasings))
+	}
+	return fmt.Sprintf("%s, %s!", randomPhrasings[i], name)
+}
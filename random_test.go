@@ -0,0 +1,46 @@
+package main
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestHelloRandomSeeded(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	expected := []string{
+		"Hello, Alice!",
+		"Greetings, Alice!",
+		"Hi, Alice!",
+		"Hey, Alice!",
+		"Greetings, Alice!",
+		"Hello, Alice!",
+	}
+
+	var result []string
+	for range expected {
+		result = append(result, HelloRandom("Alice", rng))
+	}
+	if !slices.Equal(result, expected) {
+		t.Errorf("HelloRandom sequence with seed 42 = %q, want %q", result, expected)
+	}
+}
+
+func TestHelloRandomEmptyName(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for range 20 {
+		result := HelloRandom("", rng)
+		phrase, name, ok := strings.Cut(result, ", ")
+		if !ok || name != "World!" || !slices.Contains(randomPhrasings, phrase) {
+			t.Errorf("HelloRandom(\"\") = %q, want a known phrasing greeting World", result)
+		}
+	}
+}
+
+func TestHelloRandomNilSource(t *testing.T) {
+	result := HelloRandom("Bob", nil)
+	if !strings.HasSuffix(result, ", Bob!") {
+		t.Errorf("HelloRandom(\"Bob\", nil) = %q, want a greeting for Bob", result)
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// DefaultRateLimiterSize is the number of client IPs a RateLimiter tracks
+// before evicting the least recently seen
+const DefaultRateLimiterSize = 10000
+
+// RateLimiter applies a token bucket per client IP. It tracks at most
+// MaxEntries IPs, evicting the least recently seen, so memory stays
+// bounded however many clients connect.
+type RateLimiter struct {
+	limit      rate.Limit
+	burst      int
+	MaxEntries int
+
+	mu       sync.Mutex
+	limiters *lru[string, *rate.Limiter]
+}
+
+// NewRateLimiter returns a RateLimiter allowing rps requests per second per
+// IP with bursts of up to burst requests
+func NewRateLimiter(rps float64, burst int) *RateLimiter {
+	return &RateLimiter{
+		limit:      rate.Limit(rps),
+		burst:      burst,
+		MaxEntries: DefaultRateLimiterSize,
+		limiters:   newLRU[string, *rate.Limiter](),
+	}
+}
+
+// Allow reports whether a request from ip may proceed now
+func (l *RateLimiter) Allow(ip string) bool {
+	ok, _ := l.reserve(ip)
+	return ok
+}
+
+// reserve takes a token for ip if one is available. Otherwise it reports
+// how long until the next token is due.
+func (l *RateLimiter) reserve(ip string) (bool, time.Duration) {
+	r := l.limiterFor(ip).Reserve()
+	if !r.OK() {
+		return false, 0
+	}
+	if delay := r.Delay(); delay > 0 {
+		r.Cancel()
+		return false, delay
+	}
+	return true, 0
+}
+
+// limiterFor returns the token bucket for ip, creating it if needed
+func (l *RateLimiter) limiterFor(ip string) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if limiter, ok := l.limiters.get(ip); ok {
+		return limiter
+	}
+	limiter := rate.NewLimiter(l.limit, l.burst)
+	l.limiters.put(ip, limiter, l.MaxEntries)
+	return limiter
+}
+
+// Len returns the number of IPs currently tracked
+func (l *RateLimiter) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.limiters.len()
+}
+
+// clientIP returns the IP address of the client that sent r
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// limitRate rejects requests over the client's rate limit with 429 Too
+// Many Requests and a Retry-After header. It is a no-op when s.RateLimiter
+// is nil.
+func (s *Server) limitRate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.RateLimiter == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		ok, delay := s.RateLimiter.reserve(clientIP(r))
+		if !ok {
+			retryAfter := int(math.Ceil(delay.Seconds()))
+			if retryAfter < 1 {
+				retryAfter = 1
+			}
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			http.Error(w, http.StatusText(http.StatusTooManyRequests), http.StatusTooManyRequests)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
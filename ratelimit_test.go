@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRateLimiterBurstThenThrottle(t *testing.T) {
+	l := NewRateLimiter(1, 3)
+	for i := 0; i < 3; i++ {
+		if !l.Allow("10.0.0.1") {
+			t.Fatalf("request %d within burst was throttled", i+1)
+		}
+	}
+	if l.Allow("10.0.0.1") {
+		t.Error("request over burst was allowed")
+	}
+	if !l.Allow("10.0.0.2") {
+		t.Error("request from a different IP was throttled")
+	}
+}
+
+func TestRateLimiterZeroBurstRejects(t *testing.T) {
+	if NewRateLimiter(1, 0).Allow("10.0.0.1") {
+		t.Error("limiter with zero burst allowed a request")
+	}
+}
+
+func TestRateLimiterBounded(t *testing.T) {
+	l := NewRateLimiter(1, 1)
+	l.MaxEntries = 2
+
+	l.Allow("10.0.0.1")
+	l.Allow("10.0.0.2")
+	l.Allow("10.0.0.1") // 10.0.0.1 is now most recently seen
+	l.Allow("10.0.0.3") // evicts 10.0.0.2
+
+	if n := l.Len(); n != 2 {
+		t.Errorf("Len() = %d, want 2", n)
+	}
+	if l.Allow("10.0.0.1") {
+		t.Error("10.0.0.1 was evicted; want its exhausted bucket kept")
+	}
+	if !l.Allow("10.0.0.2") {
+		t.Error("10.0.0.2 was not evicted; want a fresh bucket")
+	}
+}
+
+func TestRateLimiterManyIPs(t *testing.T) {
+	l := NewRateLimiter(1, 1)
+	l.MaxEntries = 100
+	for i := 0; i < 1000; i++ {
+		l.Allow(fmt.Sprintf("10.0.%d.%d", i/256, i%256))
+	}
+	if n := l.Len(); n != 100 {
+		t.Errorf("Len() = %d after 1000 IPs, want 100", n)
+	}
+}
+
+func TestServerRateLimit(t *testing.T) {
+	srv := newTestServer()
+	srv.RateLimiter = NewRateLimiter(0.5, 2)
+	handler := srv.Handler()
+
+	request := func(remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/hello?name=Gopher", nil)
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for i := 0; i < 2; i++ {
+		if rec := request("192.0.2.1:1234"); rec.Code != http.StatusOK {
+			t.Fatalf("request %d status = %d, want %d", i+1, rec.Code, http.StatusOK)
+		}
+	}
+	rec := request("192.0.2.1:5678")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("throttled request status = %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+	if got := rec.Header().Get("Retry-After"); got != "2" {
+		t.Errorf("Retry-After = %q, want %q", got, "2")
+	}
+	if rec := request("192.0.2.2:1234"); rec.Code != http.StatusOK {
+		t.Errorf("other client status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
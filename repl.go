@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// replPrompt is printed before each line read by RunREPL
+const replPrompt = "> "
+
+// RunREPL prompts for names on w and greets each line read from r until
+// EOF or a line reading exit or quit, in any case. Empty lines greet the
+// World. A buffered w is flushed before each read so the prompt shows.
+func RunREPL(r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	for {
+		if _, err := io.WriteString(w, replPrompt); err != nil {
+			return err
+		}
+		if f, ok := w.(interface{ Flush() error }); ok {
+			if err := f.Flush(); err != nil {
+				return err
+			}
+		}
+		if !scanner.Scan() {
+			break
+		}
+		line := scanner.Text()
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "exit", "quit":
+			return nil
+		}
+		if _, err := fmt.Fprintln(w, Hello(line)); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintln(w)
+	return err
+}
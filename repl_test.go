@@ -0,0 +1,33 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRunREPL(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"quit", "Alice\n\nBob\nquit\nCarol\n", "> Hello, Alice!\n> Hello, World!\n> Hello, Bob!\n> "},
+		{"quit mixed case", "Alice\nQuIt\n", "> Hello, Alice!\n> "},
+		{"exit with spaces", "Alice\n  EXIT  \n", "> Hello, Alice!\n> "},
+		{"eof", "Alice\nBob", "> Hello, Alice!\n> Hello, Bob!\n> \n"},
+		{"empty input", "", "> \n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := RunREPL(strings.NewReader(tt.input), &buf); err != nil {
+				t.Fatalf("RunREPL error: %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("RunREPL output = %q, want %q", buf.String(), tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// DefaultShutdownTimeout bounds how long a graceful shutdown waits for
+// in-flight requests to finish
+const DefaultShutdownTimeout = 5 * time.Second
+
+// Server serves greetings over HTTP
+type Server struct {
+	Addr            string
+	ShutdownTimeout time.Duration
+	Logger          *slog.Logger
+	Greeter         Greeter
+	Metrics         MetricsRecorder
+	History         HistoryStore
+	// RateLimiter limits /hello requests per client IP; nil disables it
+	RateLimiter *RateLimiter
+	// Cache holds recent /hello greetings; nil disables it. It should only
+	// be set when Greeter is deterministic.
+	Cache *GreetingCache
+	// MaxBatchSize limits the names in one /hello/batch request; zero or
+	// less removes the limit
+	MaxBatchSize int
+	// Clock tells the time for /hello/time greetings
+	Clock Clock
+	// TracerProvider creates the Greet span for each /hello request
+	TracerProvider trace.TracerProvider
+	// ConfigPath, when set, is reloaded into the active config whenever
+	// the process receives SIGHUP while serving
+	ConfigPath string
+	// ConfigOverrides holds the values set by flags or the environment,
+	// which take precedence over each reloaded config file
+	ConfigOverrides *Config
+
+	configMu sync.RWMutex
+	config   *Config
+
+	// started is when the server was created, for Snapshot's uptime
+	started time.Time
+}
+
+// NewServer returns a Server listening on addr with default settings. Its
+// uptime is measured from this call.
+func NewServer(addr string) *Server {
+	clock := realClock{}
+	return &Server{
+		Addr:            addr,
+		ShutdownTimeout: DefaultShutdownTimeout,
+		Logger:          slog.Default(),
+		Greeter:         DefaultGreeter{},
+		Metrics:         NewMetrics(),
+		History:         NewMemoryHistory(),
+		MaxBatchSize:    DefaultMaxBatchSize,
+		Clock:           clock,
+		TracerProvider:  otel.GetTracerProvider(),
+		started:         clock.Now(),
+	}
+}
+
+// Config returns the active config, which is never nil
+func (s *Server) Config() *Config {
+	s.configMu.RLock()
+	defer s.configMu.RUnlock()
+	if s.config == nil {
+		return &Config{}
+	}
+	return s.config
+}
+
+// SetConfig replaces the active config. Its locale becomes the default for
+// /hello requests without a locale parameter.
+func (s *Server) SetConfig(cfg *Config) {
+	s.configMu.Lock()
+	s.config = cfg
+	s.configMu.Unlock()
+}
+
+// ReloadConfig loads path and, with s.ConfigOverrides applied on top, makes
+// it the active config. If loading fails it logs and returns the error,
+// keeping the previous config.
+func (s *Server) ReloadConfig(path string) error {
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		s.Logger.Error("reloading config", "path", path, "err", err)
+		return err
+	}
+	cfg = cfg.overriddenBy(s.ConfigOverrides)
+	s.SetConfig(cfg)
+	s.Logger.Info("reloaded config", "path", path, "locale", cfg.Locale)
+	return nil
+}
+
+// reloadOnHangup reloads s.ConfigPath on each SIGHUP until ctx is done
+func (s *Server) reloadOnHangup(ctx context.Context) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			s.ReloadConfig(s.ConfigPath)
+		}
+	}
+}
+
+// Handler returns the HTTP handler serving the greeting endpoints. Every
+// request gets a request ID, is logged and has panics recovered; the
+// greeting endpoints are also rate limited.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("GET /hello", s.limitRate(http.HandlerFunc(s.handleHello)))
+	mux.Handle("POST /hello/batch", s.limitRate(http.HandlerFunc(s.handleBatch)))
+	mux.Handle("GET /hello/time", s.limitRate(http.HandlerFunc(s.handleTimeOfDay)))
+	mux.HandleFunc("GET /history", s.handleHistory)
+	mux.Handle("GET /ws", s.limitRate(http.HandlerFunc(s.ServeWebSocket)))
+	mux.HandleFunc("GET /metrics", s.handleMetrics)
+	mux.HandleFunc("GET /debug/snapshot", s.handleSnapshot)
+	return Chain(requestID, s.logRequest, s.recoverPanic)(mux)
+}
+
+// handleHello greets the name query parameter with s.Greeter, in the locale
+// given by the locale parameter or else the active config, if the greeter
+// supports it, as text or JSON. Each greeting is traced as a Greet span.
+func (s *Server) handleHello(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	locale := s.requestLocale(r)
+	_, span := startGreetSpan(s.TracerProvider, r, name, locale)
+	defer span.End()
+	g, err := s.greet(name, locale)
+	if err != nil {
+		s.greetingFailed(w, name, err)
+		return
+	}
+	s.recordGreeting(name, locale)
+	s.writeGreeting(w, r, Greeting{Name: name, Greeting: g})
+}
+
+// greetingFailed logs a greeting s.Greeter could not produce and replies
+// 500 Internal Server Error
+func (s *Server) greetingFailed(w http.ResponseWriter, name string, err error) {
+	s.Logger.Error("greeting failed", "name", name, "err", err)
+	http.Error(w, "greeting failed", http.StatusInternalServerError)
+}
+
+// handleTimeOfDay greets the name query parameter for the time of day on
+// s.Clock, as text or JSON
+func (s *Server) handleTimeOfDay(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	greeting := Greeting{Name: name, Greeting: HelloAtTime(name, s.Clock.Now())}
+	s.recordGreeting(name, defaultLocale)
+	s.writeGreeting(w, r, greeting)
+}
+
+// writeGreeting writes greeting as JSON if the request accepts it, and as a
+// line of text otherwise
+func (s *Server) writeGreeting(w http.ResponseWriter, r *http.Request, greeting Greeting) {
+	if wantsJSON(r) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(greeting)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintln(w, greeting.Greeting)
+}
+
+// requestLocale returns the supported locale named by the request's locale
+// parameter, or else by the active config
+func (s *Server) requestLocale(r *http.Request) string {
+	requested := r.URL.Query().Get("locale")
+	if requested == "" {
+		requested = s.Config().Locale
+	}
+	return lookupLocale(requested)
+}
+
+// greet returns the greeting for name in locale from s.Cache, computing and
+// caching it on a miss. Greetings that fail are not cached.
+func (s *Server) greet(name, locale string) (string, error) {
+	if s.Cache == nil {
+		return TryGreet(withLocale(s.Greeter, locale), name)
+	}
+	key := CacheKey{Name: name, Locale: locale, Template: s.Config().Template}
+	if g, ok := s.Cache.Get(key); ok {
+		return g, nil
+	}
+	g, err := TryGreet(withLocale(s.Greeter, locale), name)
+	if err != nil {
+		return "", err
+	}
+	s.Cache.Set(key, g)
+	return g, nil
+}
+
+// handleHistory reports how many times the name query parameter has been
+// greeted
+func (s *Server) handleHistory(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	count := s.History.Count(name)
+	if wantsJSON(r) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(struct {
+			Name  string `json:"name"`
+			Count int    `json:"count"`
+		}{name, count})
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintln(w, count)
+}
+
+// handleMetrics exposes the server metrics in Prometheus text format
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if err := s.Metrics.WritePrometheus(w); err != nil {
+		s.Logger.Error("writing metrics", "err", err)
+	}
+}
+
+// wantsJSON reports whether the request accepts a JSON response
+func wantsJSON(r *http.Request) bool {
+	return strings.Contains(r.Header.Get("Accept"), "application/json")
+}
+
+// ListenAndServe listens on s.Addr and serves until ctx is done
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	ln, err := net.Listen("tcp", s.Addr)
+	if err != nil {
+		return err
+	}
+	return s.Serve(ctx, ln)
+}
+
+// Serve serves greetings on ln until ctx is done, then shuts down
+// gracefully, waiting at most s.ShutdownTimeout for in-flight requests
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	srv := &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	errc := make(chan error, 1)
+	go func() { errc <- srv.Serve(ln) }()
+	s.Logger.Info("serving greetings", "addr", ln.Addr().String())
+	if s.ConfigPath != "" {
+		go s.reloadOnHangup(ctx)
+	}
+
+	select {
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+	}
+
+	s.Logger.Info("shutting down", "timeout", s.ShutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.ShutdownTimeout)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("shutdown: %w", err)
+	}
+	if err := <-errc; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// ServeGreetings serves greetings on addr until SIGINT or SIGTERM
+func ServeGreetings(addr string) error {
+	return serveUntilSignal(NewServer(addr).ListenAndServe)
+}
+
+// serveUntilSignal runs each serve function until the process receives
+// SIGINT or SIGTERM, or until one of them fails, and returns the first error
+func serveUntilSignal(serves ...func(context.Context) error) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errc := make(chan error, len(serves))
+	for _, serve := range serves {
+		go func() {
+			err := serve(ctx)
+			if err != nil {
+				stop()
+			}
+			errc <- err
+		}()
+	}
+	var first error
+	for range serves {
+		if err := <-errc; err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
@@ -0,0 +1,322 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// newTestServer returns a Server whose logs are discarded
+func newTestServer() *Server {
+	srv := NewServer("")
+	srv.Logger = discardLogger()
+	return srv
+}
+
+// discardLogger returns a logger that drops every record
+func discardLogger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
+
+func TestHandleHelloText(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		expected string
+	}{
+		{"no name", "/hello", "Hello, World!\n"},
+		{"empty name", "/hello?name=", "Hello, World!\n"},
+		{"with name", "/hello?name=Gopher", "Hello, Gopher!\n"},
+		{"with locale", "/hello?name=Ana&locale=es", "¡Hola, Ana!\n"},
+		{"unknown locale", "/hello?name=Ana&locale=xx", "Hello, Ana!\n"},
+	}
+
+	srv := newTestServer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "text/plain; charset=utf-8" {
+				t.Errorf("Content-Type = %q, want text/plain", ct)
+			}
+			if rec.Body.String() != tt.expected {
+				t.Errorf("body = %q, want %q", rec.Body.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestHandleHelloJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		expected Greeting
+	}{
+		{"no name", "/hello", Greeting{Name: "", Greeting: "Hello, World!"}},
+		{"with name", "/hello?name=Gopher", Greeting{Name: "Gopher", Greeting: "Hello, Gopher!"}},
+	}
+
+	srv := newTestServer()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			req.Header.Set("Accept", "application/json")
+			rec := httptest.NewRecorder()
+			srv.Handler().ServeHTTP(rec, req)
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Content-Type = %q, want application/json", ct)
+			}
+			var got Greeting
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("decoding body %q: %v", rec.Body.String(), err)
+			}
+			if got != tt.expected {
+				t.Errorf("body = %+v, want %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHandleHelloMethodNotAllowed(t *testing.T) {
+	rec := httptest.NewRecorder()
+	newTestServer().Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/hello", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
+
+func TestServerHTTP(t *testing.T) {
+	ts := httptest.NewServer(newTestServer().Handler())
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/hello?name=Gopher")
+	if err != nil {
+		t.Fatalf("GET /hello: %v", err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading body: %v", err)
+	}
+	if string(body) != "Hello, Gopher!\n" {
+		t.Errorf("body = %q, want %q", body, "Hello, Gopher!\n")
+	}
+}
+
+func TestListenAndServeAddrError(t *testing.T) {
+	srv := newTestServer()
+	srv.Addr = "not-a-valid-address"
+	if err := srv.ListenAndServe(context.Background()); err == nil {
+		t.Error("ListenAndServe with invalid address returned nil error")
+	}
+}
+
+func TestServeGracefulShutdown(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	srv := newTestServer()
+	srv.ShutdownTimeout = time.Second
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(ctx, ln) }()
+
+	resp, err := http.Get("http://" + ln.Addr().String() + "/hello")
+	if err != nil {
+		t.Fatalf("GET /hello: %v", err)
+	}
+	resp.Body.Close()
+
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Serve returned %v after shutdown, want nil", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve did not return after context cancellation")
+	}
+}
+
+func TestHandleHelloLogsRequest(t *testing.T) {
+	var buf bytes.Buffer
+	srv := NewServer("")
+	srv.Logger = slog.New(slog.NewTextHandler(&buf, nil))
+
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/hello?name=Gopher", nil))
+
+	out := buf.String()
+	for _, want := range []string{"level=INFO", "msg=request", "name=Gopher", "request_id=", "duration="} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log output %q does not contain %q", out, want)
+		}
+	}
+}
+
+func TestServerReloadConfig(t *testing.T) {
+	var buf bytes.Buffer
+	srv := NewServer("")
+	srv.Logger = slog.New(slog.NewTextHandler(&buf, nil))
+	handler := srv.Handler()
+
+	greet := func() string {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/hello?name=Ana", nil))
+		return rec.Body.String()
+	}
+
+	if got := greet(); got != "Hello, Ana!\n" {
+		t.Fatalf("before reload body = %q, want %q", got, "Hello, Ana!\n")
+	}
+
+	if err := srv.ReloadConfig(writeConfig(t, "config.yaml", "locale: es\n")); err != nil {
+		t.Fatalf("ReloadConfig: %v", err)
+	}
+	if got := srv.Config().Locale; got != "es" {
+		t.Errorf("active locale = %q, want %q", got, "es")
+	}
+	if got := greet(); got != "¡Hola, Ana!\n" {
+		t.Errorf("after reload body = %q, want %q", got, "¡Hola, Ana!\n")
+	}
+
+	if err := srv.ReloadConfig(writeConfig(t, "broken.json", "{")); err == nil {
+		t.Fatal("ReloadConfig with malformed file succeeded, want error")
+	}
+	if got := srv.Config().Locale; got != "es" {
+		t.Errorf("active locale after failed reload = %q, want %q", got, "es")
+	}
+	if !strings.Contains(buf.String(), "level=ERROR") {
+		t.Errorf("log output %q does not report the failed reload", buf.String())
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/hello?name=Ana&locale=fr", nil))
+	if got := rec.Body.String(); got != "Bonjour, Ana!\n" {
+		t.Errorf("explicit locale body = %q, want %q", got, "Bonjour, Ana!\n")
+	}
+}
+
+func TestServerReloadConfigKeepsOverrides(t *testing.T) {
+	srv := newTestServer()
+	srv.ConfigOverrides = &Config{Locale: "de"}
+	srv.SetConfig(&Config{Locale: "de"})
+
+	if err := srv.ReloadConfig(writeConfig(t, "config.yaml", "locale: es\nformat: json\n")); err != nil {
+		t.Fatalf("ReloadConfig: %v", err)
+	}
+	expected := Config{Locale: "de", Format: "json"}
+	if got := srv.Config(); *got != expected {
+		t.Errorf("active config = %+v, want %+v", *got, expected)
+	}
+
+	rec := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/hello?name=Al", nil))
+	if got := rec.Body.String(); got != "Hallo, Al!\n" {
+		t.Errorf("after reload body = %q, want %q", got, "Hallo, Al!\n")
+	}
+}
+
+func TestHandleHelloTimeOfDayGreeter(t *testing.T) {
+	clock := newFakeClock(time.Date(2024, time.March, 1, 7, 0, 0, 0, time.UTC))
+	srv := newTestServer()
+	srv.Clock = clock
+	srv.Greeter = CompositeGreeter{Base: TimeOfDayGreeter{Clock: srv.Clock}, Transforms: []Transform{ShoutTransform}}
+	handler := srv.Handler()
+
+	for _, want := range []string{"GOOD MORNING, AL!\n", "GOOD EVENING, AL!\n"} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/hello?name=Al", nil))
+		if rec.Body.String() != want {
+			t.Errorf("at %s body = %q, want %q", clock.Now().Format("15:04"), rec.Body.String(), want)
+		}
+		clock.Advance(12 * time.Hour)
+	}
+}
+
+func TestHandleTimeOfDay(t *testing.T) {
+	clock := newFakeClock(time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC))
+	srv := newTestServer()
+	srv.Clock = clock
+	handler := srv.Handler()
+
+	tests := []struct {
+		hour   int
+		prefix string
+	}{
+		{3, "Good night"},
+		{6, "Good morning"},
+		{11, "Good morning"},
+		{12, "Good afternoon"},
+		{18, "Good evening"},
+		{22, "Good night"},
+	}
+	hour := 0
+	for _, tt := range tests {
+		clock.Advance(time.Duration(tt.hour-hour) * time.Hour)
+		hour = tt.hour
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/hello/time?name=Alice", nil))
+		if want := tt.prefix + ", Alice!\n"; rec.Body.String() != want {
+			t.Errorf("at %02d:00 body = %q, want %q", tt.hour, rec.Body.String(), want)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/hello/time", nil)
+	req.Header.Set("Accept", "application/json")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if got, want := rec.Body.String(), `{"name":"","greeting":"Good night, World!"}`+"\n"; got != want {
+		t.Errorf("JSON body = %q, want %q", got, want)
+	}
+}
+
+func TestServerTemplateRenderError(t *testing.T) {
+	tmpl, err := parseGreetingTemplate(`{{if gt (len .Name) 10}}{{index .Name 100}}{{end}}Hi, {{.Name}}!`)
+	if err != nil {
+		t.Fatalf("parseGreetingTemplate: %v", err)
+	}
+	srv := newTestServer()
+	srv.Greeter = CompositeGreeter{Base: templateGreeter{t: tmpl}, Transforms: []Transform{ShoutTransform}}
+	handler := srv.Handler()
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		name, wantCode, wantBody := "Al", http.StatusOK, "HI, AL!\n"
+		if i%2 == 1 {
+			name, wantCode, wantBody = "Bartholomew", http.StatusInternalServerError, "greeting failed\n"
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/hello?name="+name, nil))
+			if rec.Code != wantCode || rec.Body.String() != wantBody {
+				t.Errorf("GET /hello?name=%s = %d %q, want %d %q", name, rec.Code, rec.Body.String(), wantCode, wantBody)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := srv.History.Count("Bartholomew"); got != 0 {
+		t.Errorf("History.Count(Bartholomew) = %d, want failed greetings unrecorded", got)
+	}
+}
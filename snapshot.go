@@ -0,0 +1,73 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// SnapshotData is a point-in-time view of a running server
+type SnapshotData struct {
+	Uptime         string            `json:"uptime"`
+	TotalGreetings uint64            `json:"total_greetings"`
+	ByLocale       map[string]uint64 `json:"greetings_by_locale"`
+	// Config is the active config with secrets redacted
+	Config *Config `json:"config"`
+}
+
+// recordGreeting counts one greeting of name in locale in the metrics and
+// history
+func (s *Server) recordGreeting(name, locale string) {
+	s.Metrics.IncGreetings(locale)
+	s.History.Record(name)
+}
+
+// Snapshot returns the server's uptime, greeting counts and redacted
+// config. The counts are those s.Metrics reports, and are zero when it is
+// not a StatsReporter.
+func (s *Server) Snapshot() SnapshotData {
+	var stats GreetingStats
+	if r, ok := s.Metrics.(StatsReporter); ok {
+		stats = r.Stats()
+	}
+	if stats.ByLocale == nil {
+		stats.ByLocale = map[string]uint64{}
+	}
+	return SnapshotData{
+		Uptime:         s.Clock.Now().Sub(s.started).Round(time.Second).String(),
+		TotalGreetings: stats.Total,
+		ByLocale:       stats.ByLocale,
+		Config:         s.Config().Redacted(),
+	}
+}
+
+// handleSnapshot writes Snapshot as JSON
+func (s *Server) handleSnapshot(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.Snapshot())
+}
+
+// DumpSnapshot fetches the snapshot of the server at baseURL, such as
+// http://localhost:8080, and writes it to w as indented JSON
+func DumpSnapshot(baseURL string, timeout time.Duration, w io.Writer) error {
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Get(strings.TrimSuffix(baseURL, "/") + "/debug/snapshot")
+	if err != nil {
+		return fmt.Errorf("dump snapshot: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("dump snapshot: server returned %s", resp.Status)
+	}
+
+	var data SnapshotData
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return fmt.Errorf("dump snapshot: decoding response: %w", err)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(data)
+}
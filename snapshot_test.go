@@ -0,0 +1,125 @@
+package main
+
+import (
+	"encoding/json"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSnapshot(t *testing.T) {
+	clock := newFakeClock(time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC))
+	srv := newTestServer()
+	srv.Clock = clock
+	srv.started = clock.Now()
+	srv.SetConfig(&Config{DefaultName: "Dee", Locale: "fr"})
+	handler := srv.Handler()
+
+	for _, target := range []string{"/hello?name=Ann", "/hello?name=Bob&locale=es", "/hello", "/hello/time?name=Cy"} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("GET %s status = %d, want 200", target, rec.Code)
+		}
+	}
+	clock.Advance(90 * time.Second)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/snapshot", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /debug/snapshot status = %d, want 200", rec.Code)
+	}
+	var got SnapshotData
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("decoding snapshot: %v", err)
+	}
+
+	if got.Uptime != "1m30s" {
+		t.Errorf("Uptime = %q, want 1m30s", got.Uptime)
+	}
+	if got.TotalGreetings != 4 {
+		t.Errorf("TotalGreetings = %d, want 4", got.TotalGreetings)
+	}
+	wantByLocale := map[string]uint64{"fr": 2, "es": 1, defaultLocale: 1}
+	if !maps.Equal(got.ByLocale, wantByLocale) {
+		t.Errorf("ByLocale = %v, want %v", got.ByLocale, wantByLocale)
+	}
+	if got.Config.Locale != "fr" {
+		t.Errorf("Config.Locale = %q, want fr", got.Config.Locale)
+	}
+	if got.Config.DefaultName != "" {
+		t.Errorf("Config.DefaultName = %q, want it blanked", got.Config.DefaultName)
+	}
+	if srv.Config().DefaultName != "Dee" {
+		t.Error("snapshot redaction blanked the active config")
+	}
+}
+
+func TestSnapshotEmpty(t *testing.T) {
+	got := newTestServer().Snapshot()
+	if got.TotalGreetings != 0 || got.ByLocale == nil || len(got.ByLocale) != 0 {
+		t.Errorf("Snapshot() of a new server = %+v, want no greetings", got)
+	}
+}
+
+func TestDumpSnapshot(t *testing.T) {
+	srv := newTestServer()
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	resp, err := http.Get(ts.URL + "/hello?name=Ann")
+	if err != nil {
+		t.Fatalf("GET /hello: %v", err)
+	}
+	resp.Body.Close()
+	var out strings.Builder
+	if err := DumpSnapshot(ts.URL, time.Second, &out); err != nil {
+		t.Fatalf("DumpSnapshot error: %v", err)
+	}
+	for _, want := range []string{`  "total_greetings": 1,`, `"greetings_by_locale": {`} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("DumpSnapshot output missing %q:\n%s", want, out.String())
+		}
+	}
+
+	broken := httptest.NewServer(http.NotFoundHandler())
+	defer broken.Close()
+	if err := DumpSnapshot(broken.URL, time.Second, &out); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("DumpSnapshot against a 404 = %v, want error containing 404", err)
+	}
+}
+
+func TestRunDump(t *testing.T) {
+	ts := httptest.NewServer(newTestServer().Handler())
+	defer ts.Close()
+
+	stdout, stderr, code := runCLI(t, "", "-dump", "-addr", strings.TrimPrefix(ts.URL, "http://"))
+	if code != ExitOK {
+		t.Fatalf("-dump exit code = %d, stderr %q", code, stderr)
+	}
+	if !strings.Contains(stdout, `"uptime"`) {
+		t.Errorf("-dump output = %q, want a snapshot", stdout)
+	}
+}
+
+func TestSnapshotMatchesMetrics(t *testing.T) {
+	srv := newTestServer()
+	handler := srv.Handler()
+	for _, target := range []string{"/hello", "/hello?locale=es", "/hello?locale=es"} {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+	}
+
+	got := srv.Snapshot()
+	metrics := srv.Metrics.(*Metrics)
+	if got.TotalGreetings != metrics.Total() || got.ByLocale["es"] != metrics.LocaleCount("es") {
+		t.Errorf("Snapshot() = %+v, want the counts in srv.Metrics", got)
+	}
+
+	srv.Metrics = &fakeMetrics{}
+	if got := srv.Snapshot(); got.TotalGreetings != 0 || len(got.ByLocale) != 0 {
+		t.Errorf("Snapshot() with a MetricsRecorder that is not a StatsReporter = %+v, want no counts", got)
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+)
+
+// maxLineSize is the longest input line HelloStream accepts
+const maxLineSize = 1 << 20
+
+// StreamError reports that writing greetings failed partway through a
+// stream. Written greetings reached the writer in full, so a caller can
+// resume from input line Written+1.
+type StreamError struct {
+	Written int
+	Err     error
+}
+
+// Error describes the failure and how far the stream got
+func (e *StreamError) Error() string {
+	return fmt.Sprintf("write greetings: failed after %d lines: %v", e.Written, e.Err)
+}
+
+// Unwrap returns the underlying write error
+func (e *StreamError) Unwrap() error {
+	return e.Err
+}
+
+// HelloStream reads names from r one per line and writes one greeting per
+// line to w. Blank lines are greeted with the default. Input is processed
+// line by line, so arbitrarily long streams use constant memory. If writing
+// to w fails, HelloStream stops at once and returns a *StreamError.
+func HelloStream(r io.Reader, w io.Writer) error {
+	return HelloStreamContext(context.Background(), r, w)
+}
+
+// HelloStreamContext is like HelloStream but stops before the next line
+// once ctx is done and returns ctx.Err(). Greetings for lines already read
+// are flushed to w. A Read blocked on r is not interrupted.
+func HelloStreamContext(ctx context.Context, r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineSize)
+	lw := newLineWriter(w)
+	for scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			if ferr := lw.Flush(); ferr != nil {
+				return ferr
+			}
+			return err
+		}
+		if err := lw.WriteLine(Hello(scanner.Text())); err != nil {
+			return err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		if ferr := lw.Flush(); ferr != nil {
+			return ferr
+		}
+		return err
+	}
+	return lw.Flush()
+}
+
+// lineWriter buffers lines for an io.Writer and tracks how many of them
+// the writer has accepted in full, reporting write failures as
+// *StreamError
+type lineWriter struct {
+	bw       *bufio.Writer
+	out      countingWriter
+	buffered int64   // bytes handed to bw so far
+	ends     []int64 // end offsets of lines not yet known to be written
+	written  int
+}
+
+// newLineWriter returns a lineWriter writing to w
+func newLineWriter(w io.Writer) *lineWriter {
+	lw := &lineWriter{out: countingWriter{w: w}}
+	lw.bw = bufio.NewWriter(&lw.out)
+	return lw
+}
+
+// WriteLine writes s followed by a newline
+func (lw *lineWriter) WriteLine(s string) error {
+	n, err := lw.bw.WriteString(s + "\n")
+	lw.buffered += int64(n)
+	lw.ends = append(lw.ends, lw.buffered)
+	return lw.check(err)
+}
+
+// Flush writes any buffered lines
+func (lw *lineWriter) Flush() error {
+	return lw.check(lw.bw.Flush())
+}
+
+// check counts the lines the writer has accepted and wraps err, if any
+func (lw *lineWriter) check(err error) error {
+	i := 0
+	for i < len(lw.ends) && lw.ends[i] <= lw.out.n {
+		i++
+	}
+	lw.written += i
+	lw.ends = lw.ends[i:]
+	if err != nil {
+		return &StreamError{Written: lw.written, Err: err}
+	}
+	return nil
+}
+
+// countingWriter counts the bytes written to w
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestHelloStream(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty input", "", ""},
+		{"single name", "Gopher\n", "Hello, Gopher!\n"},
+		{"no trailing newline", "Alice\nBob", "Hello, Alice!\nHello, Bob!\n"},
+		{"blank lines", "Alice\n\nBob\n", "Hello, Alice!\nHello, World!\nHello, Bob!\n"},
+		{"crlf line endings", "Alice\r\nBob\r\n", "Hello, Alice!\nHello, Bob!\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := HelloStream(strings.NewReader(tt.input), &buf); err != nil {
+				t.Fatalf("HelloStream error: %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("HelloStream output = %q, want %q", buf.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestHelloStreamLargeInput(t *testing.T) {
+	const lines = 100000
+	input := strings.Repeat("Gopher\n", lines)
+	var buf bytes.Buffer
+	if err := HelloStream(strings.NewReader(input), &buf); err != nil {
+		t.Fatalf("HelloStream error: %v", err)
+	}
+	if got := strings.Count(buf.String(), "Hello, Gopher!\n"); got != lines {
+		t.Errorf("HelloStream wrote %d greetings, want %d", got, lines)
+	}
+}
+
+func TestHelloStreamLineTooLong(t *testing.T) {
+	input := strings.Repeat("x", maxLineSize+1)
+	err := HelloStream(strings.NewReader(input), io.Discard)
+	if !errors.Is(err, bufio.ErrTooLong) {
+		t.Errorf("HelloStream error = %v, want %v", err, bufio.ErrTooLong)
+	}
+}
+
+type errReader struct{ err error }
+
+func (r errReader) Read([]byte) (int, error) { return 0, r.err }
+
+func TestHelloStreamReadError(t *testing.T) {
+	readErr := errors.New("read failed")
+	r := io.MultiReader(strings.NewReader("Alice\n"), errReader{readErr})
+	var buf bytes.Buffer
+	if err := HelloStream(r, &buf); !errors.Is(err, readErr) {
+		t.Errorf("HelloStream error = %v, want %v", err, readErr)
+	}
+	if buf.String() != "Hello, Alice!\n" {
+		t.Errorf("HelloStream output = %q, want greetings read before the error", buf.String())
+	}
+}
+
+// cancelReader returns first on the first Read, then cancels its context
+// and returns rest
+type cancelReader struct {
+	first, rest string
+	cancel      context.CancelFunc
+	reads       int
+}
+
+func (r *cancelReader) Read(p []byte) (int, error) {
+	r.reads++
+	switch r.reads {
+	case 1:
+		return copy(p, r.first), nil
+	case 2:
+		r.cancel()
+		return copy(p, r.rest), nil
+	}
+	return 0, io.EOF
+}
+
+func TestHelloStreamContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	r := &cancelReader{first: "Alice\n", rest: "Bob\nCarol\n", cancel: cancel}
+
+	var buf bytes.Buffer
+	err := HelloStreamContext(ctx, r, &buf)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("HelloStreamContext error = %v, want %v", err, context.Canceled)
+	}
+	if buf.String() != "Hello, Alice!\n" {
+		t.Errorf("HelloStreamContext output = %q, want only the greeting read before cancellation", buf.String())
+	}
+}
+
+func TestHelloStreamContextAlreadyCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var buf bytes.Buffer
+	err := HelloStreamContext(ctx, strings.NewReader("Alice\nBob\n"), &buf)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("HelloStreamContext error = %v, want %v", err, context.Canceled)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("HelloStreamContext output = %q, want nothing", buf.String())
+	}
+}
+
+// limitWriter accepts up to n bytes and then fails every write
+type limitWriter struct {
+	buf bytes.Buffer
+	n   int
+	err error
+}
+
+func (w *limitWriter) Write(p []byte) (int, error) {
+	if len(p) > w.n {
+		written, _ := w.buf.Write(p[:w.n])
+		w.n = 0
+		return written, w.err
+	}
+	w.n -= len(p)
+	return w.buf.Write(p)
+}
+
+func TestHelloStreamWriteError(t *testing.T) {
+	const greeting = "Hello, Gopher!\n" // 15 bytes
+	writeErr := errors.New("broken pipe")
+
+	tests := []struct {
+		name    string
+		lines   int
+		limit   int
+		written int
+	}{
+		{"fails immediately", 10, 0, 0},
+		{"fails mid line", 10, 20, 1},
+		{"fails on line boundary", 10, 45, 3},
+		{"fails in a later buffer", 1000, 5000, 333},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &limitWriter{n: tt.limit, err: writeErr}
+			input := strings.NewReader(strings.Repeat("Gopher\n", tt.lines))
+			err := HelloStream(input, w)
+
+			var streamErr *StreamError
+			if !errors.As(err, &streamErr) {
+				t.Fatalf("HelloStream error = %v, want a *StreamError", err)
+			}
+			if !errors.Is(err, writeErr) {
+				t.Errorf("HelloStream error = %v, want it to wrap %v", err, writeErr)
+			}
+			if streamErr.Written != tt.written {
+				t.Errorf("Written = %d, want %d", streamErr.Written, tt.written)
+			}
+			if full := strings.Count(w.buf.String(), greeting); full != streamErr.Written {
+				t.Errorf("writer holds %d complete greetings, Written = %d", full, streamErr.Written)
+			}
+			if !strings.Contains(err.Error(), "failed after") {
+				t.Errorf("HelloStream error %q does not report the count", err)
+			}
+		})
+	}
+}
+
+// countingReader counts Read calls
+type countingReader struct {
+	r     io.Reader
+	reads int
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	c.reads++
+	return c.r.Read(p)
+}
+
+func TestHelloStreamStopsOnWriteError(t *testing.T) {
+	r := &countingReader{r: strings.NewReader(strings.Repeat("Gopher\n", 1<<20))}
+	if err := HelloStream(r, failingWriter{}); err == nil {
+		t.Fatal("HelloStream to a failing writer = nil error")
+	}
+	if r.reads > 2 {
+		t.Errorf("HelloStream kept reading after the write failed: %d reads", r.reads)
+	}
+}
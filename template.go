@@ -0,0 +1,78 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+)
+
+// templateData is the value greeting templates are rendered with
+type templateData struct {
+	Name string
+}
+
+// newTemplateData returns the template data for name, using World when the
+// name is empty so templates need not special-case it
+func newTemplateData(name string) templateData {
+	if name == "" {
+		name = "World"
+	}
+	return templateData{Name: name}
+}
+
+// ErrInvalidTemplate is returned for a greeting template that does not
+// parse or render
+var ErrInvalidTemplate = errors.New("invalid greeting template")
+
+// parseGreetingTemplate parses tmpl and checks that it renders, so that
+// references to unknown fields are reported up front
+func parseGreetingTemplate(tmpl string) (*template.Template, error) {
+	t, err := template.New("greeting").Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+	}
+	if err := t.Execute(io.Discard, newTemplateData("")); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+	}
+	return t, nil
+}
+
+// renderGreeting renders a parsed greeting template for name
+func renderGreeting(t *template.Template, name string) (string, error) {
+	var b strings.Builder
+	if err := t.Execute(&b, newTemplateData(name)); err != nil {
+		return "", fmt.Errorf("render greeting template: %w", err)
+	}
+	return b.String(), nil
+}
+
+// templateGreeter greets by rendering a parsed greeting template
+type templateGreeter struct {
+	t *template.Template
+}
+
+// Greet returns the rendered greeting for name, or "" if it fails to render
+func (g templateGreeter) Greet(name string) string {
+	s, _ := g.TryGreet(name)
+	return s
+}
+
+// TryGreet returns the rendered greeting for name
+func (g templateGreeter) TryGreet(name string) (string, error) {
+	return renderGreeting(g.t, name)
+}
+
+// HelloTemplate renders tmpl as a Go text/template with a .Name field. An
+// empty template falls back to Hello.
+func HelloTemplate(name, tmpl string) (string, error) {
+	if tmpl == "" {
+		return Hello(name), nil
+	}
+	t, err := parseGreetingTemplate(tmpl)
+	if err != nil {
+		return "", err
+	}
+	return renderGreeting(t, name)
+}
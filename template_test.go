@@ -0,0 +1,56 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestHelloTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		tmpl     string
+		expected string
+	}{
+		{"custom template", "Alice", "Hey, {{.Name}}!", "Hey, Alice!"},
+		{"custom template empty name", "", "Welcome back, {{.Name}}.", "Welcome back, World."},
+		{"default fallback", "Alice", "", "Hello, Alice!"},
+		{"default fallback empty name", "", "", "Hello, World!"},
+		{"no placeholder", "Alice", "Hi there", "Hi there"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := HelloTemplate(tt.input, tt.tmpl)
+			if err != nil {
+				t.Fatalf("HelloTemplate(%q, %q) error: %v", tt.input, tt.tmpl, err)
+			}
+			if result != tt.expected {
+				t.Errorf("HelloTemplate(%q, %q) = %q, want %q", tt.input, tt.tmpl, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHelloTemplateInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		tmpl string
+	}{
+		{"unclosed action", "Hey, {{.Name"},
+		{"unknown function", "Hey, {{shout .Name}}!"},
+		{"unknown field", "Hey, {{.Nickname}}!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := HelloTemplate("Alice", tt.tmpl)
+			if err == nil {
+				t.Fatalf("HelloTemplate(%q) = %q, want error", tt.tmpl, result)
+			}
+			if !errors.Is(err, ErrInvalidTemplate) {
+				t.Errorf("HelloTemplate(%q) error %v does not match ErrInvalidTemplate", tt.tmpl, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimeOfDaySalutation returns the salutation for the hour of t: morning
+// from 06:00, afternoon from 12:00, evening from 18:00 and night from 22:00
+func TimeOfDaySalutation(t time.Time) string {
+	switch h := t.Hour(); {
+	case h >= 6 && h < 12:
+		return "Good morning"
+	case h >= 12 && h < 18:
+		return "Good afternoon"
+	case h >= 18 && h < 22:
+		return "Good evening"
+	default:
+		return "Good night"
+	}
+}
+
+// HelloAtTime returns a greeting suited to the time of day of t
+func HelloAtTime(name string, t time.Time) string {
+	if name == "" {
+		name = "World"
+	}
+	return fmt.Sprintf("%s, %s!", TimeOfDaySalutation(t), name)
+}
+
+// TimeOfDayGreeter greets with HelloAtTime at the time told by Clock
+type TimeOfDayGreeter struct {
+	Clock Clock
+}
+
+// Greet returns HelloAtTime(name, g.Clock.Now())
+func (g TimeOfDayGreeter) Greet(name string) string {
+	return HelloAtTime(name, g.Clock.Now())
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHelloAtTime(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, time.January, 1, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name     string
+		input    string
+		time     time.Time
+		expected string
+	}{
+		{"midnight", "Alice", at(0, 0), "Good night, Alice!"},
+		{"before morning", "Alice", at(5, 59), "Good night, Alice!"},
+		{"morning starts", "Alice", at(6, 0), "Good morning, Alice!"},
+		{"before afternoon", "Alice", at(11, 59), "Good morning, Alice!"},
+		{"afternoon starts", "Alice", at(12, 0), "Good afternoon, Alice!"},
+		{"before evening", "Alice", at(17, 59), "Good afternoon, Alice!"},
+		{"evening starts", "Alice", at(18, 0), "Good evening, Alice!"},
+		{"before night", "Alice", at(21, 59), "Good evening, Alice!"},
+		{"night starts", "Alice", at(22, 0), "Good night, Alice!"},
+		{"empty name morning", "", at(6, 0), "Good morning, World!"},
+		{"empty name night", "", at(23, 0), "Good night, World!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := HelloAtTime(tt.input, tt.time)
+			if result != tt.expected {
+				t.Errorf("HelloAtTime(%q, %s) = %q, want %q", tt.input, tt.time.Format("15:04"), result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName is the instrumentation name of the spans this program creates
+const tracerName = "github.com/Timmyae/codex-universal"
+
+// Span attribute keys for greetings
+const (
+	attrNameLength = attribute.Key("greeting.name_length")
+	attrLocale     = attribute.Key("greeting.locale")
+)
+
+// propagator carries W3C trace context and baggage in HTTP headers between
+// Client and Server
+var propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+// startGreetSpan starts the server span for a /hello request, continuing
+// any trace propagated in its headers
+func startGreetSpan(tp trace.TracerProvider, r *http.Request, name, locale string) (context.Context, trace.Span) {
+	ctx := propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+	return tp.Tracer(tracerName).Start(ctx, "Greet",
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attrNameLength.Int(len([]rune(name))),
+			attrLocale.String(locale),
+		),
+	)
+}
+
+// injectTraceContext adds the trace context of ctx to the request headers
+func injectTraceContext(ctx context.Context, req *http.Request) {
+	propagator.Inject(ctx, propagation.HeaderCarrier(req.Header))
+}
+
+// ErrInvalidEndpoint is matched by every error validateEndpoint returns
+var ErrInvalidEndpoint = errors.New("invalid OTLP endpoint")
+
+// validateEndpoint checks that endpoint is an absolute http or https URL
+func validateEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrInvalidEndpoint, endpoint, err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return fmt.Errorf("%w %q: want an http or https URL such as http://localhost:4318", ErrInvalidEndpoint, endpoint)
+	}
+	return nil
+}
+
+// SetupTracing installs a global tracer provider exporting spans over
+// OTLP/HTTP to endpoint, a URL such as http://localhost:4318. With an empty
+// endpoint it does nothing and spans are dropped. The returned function
+// flushes and stops the exporter.
+func SetupTracing(ctx context.Context, endpoint string) (func(context.Context) error, error) {
+	if endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+	if err := validateEndpoint(endpoint); err != nil {
+		return nil, fmt.Errorf("setup tracing: %w", err)
+	}
+	exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
+	if err != nil {
+		return nil, fmt.Errorf("setup tracing: %w", err)
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", binaryName))),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagator)
+	return tp.Shutdown, nil
+}
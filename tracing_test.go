@@ -0,0 +1,132 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// newRecordingProvider returns a tracer provider that records ended spans
+func newRecordingProvider(t *testing.T) (*sdktrace.TracerProvider, *tracetest.SpanRecorder) {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	t.Cleanup(func() { tp.Shutdown(context.Background()) })
+	return tp, recorder
+}
+
+// spanAttrs returns the attributes of span as a map
+func spanAttrs(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestHandleHelloSpans(t *testing.T) {
+	tp, recorder := newRecordingProvider(t)
+	srv := newTestServer()
+	srv.TracerProvider = tp
+	handler := srv.Handler()
+
+	requests := []struct {
+		target     string
+		nameLength int64
+		locale     string
+	}{
+		{"/hello?name=Alice", 5, "en"},
+		{"/hello?name=José&locale=es", 4, "es"},
+		{"/hello", 0, "en"},
+	}
+	for _, req := range requests {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, req.target, nil))
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != len(requests) {
+		t.Fatalf("recorded %d spans, want %d", len(spans), len(requests))
+	}
+	for i, span := range spans {
+		want := requests[i]
+		if span.Name() != "Greet" {
+			t.Errorf("span %d name = %q, want %q", i, span.Name(), "Greet")
+		}
+		attrs := spanAttrs(span)
+		if got := attrs[attrNameLength].AsInt64(); got != want.nameLength {
+			t.Errorf("span %d %s = %d, want %d", i, attrNameLength, got, want.nameLength)
+		}
+		if got := attrs[attrLocale].AsString(); got != want.locale {
+			t.Errorf("span %d %s = %q, want %q", i, attrLocale, got, want.locale)
+		}
+	}
+}
+
+func TestClientPropagatesTraceContext(t *testing.T) {
+	tp, recorder := newRecordingProvider(t)
+	srv := newTestServer()
+	srv.TracerProvider = tp
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "caller")
+	if _, err := NewClient(ts.URL).Greet(ctx, "Alice"); err != nil {
+		t.Fatalf("Greet: %v", err)
+	}
+	parent.End()
+
+	var greet sdktrace.ReadOnlySpan
+	for _, span := range recorder.Ended() {
+		if span.Name() == "Greet" {
+			greet = span
+		}
+	}
+	if greet == nil {
+		t.Fatal("no Greet span recorded")
+	}
+	if got, want := greet.Parent().SpanID(), parent.SpanContext().SpanID(); got != want {
+		t.Errorf("Greet span parent = %s, want caller span %s", got, want)
+	}
+	if got, want := greet.SpanContext().TraceID(), parent.SpanContext().TraceID(); got != want {
+		t.Errorf("Greet span trace = %s, want %s", got, want)
+	}
+}
+
+func TestSetupTracingDisabled(t *testing.T) {
+	shutdown, err := SetupTracing(context.Background(), "")
+	if err != nil {
+		t.Fatalf("SetupTracing with no endpoint: %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("shutdown: %v", err)
+	}
+}
+
+func TestValidateEndpoint(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		ok       bool
+	}{
+		{"http://localhost:4318", true},
+		{"https://collector.example.com/v1/traces", true},
+		{"::bad", false},
+		{"localhost:4318", false},
+		{"ftp://localhost:4318", false},
+		{"http://", false},
+	}
+	for _, tt := range tests {
+		err := validateEndpoint(tt.endpoint)
+		if tt.ok && err != nil {
+			t.Errorf("validateEndpoint(%q) = %v, want nil", tt.endpoint, err)
+		}
+		if !tt.ok && !errors.Is(err, ErrInvalidEndpoint) {
+			t.Errorf("validateEndpoint(%q) = %v, want ErrInvalidEndpoint", tt.endpoint, err)
+		}
+	}
+}
@@ -0,0 +1,18 @@
+package main
+
+import "strings"
+
+// Shout returns s in upper case, e.g. "HELLO, ALICE!"
+func Shout(s string) string {
+	return strings.ToUpper(s)
+}
+
+// Reverse returns s with its runes in reverse order. Multibyte characters
+// are kept intact; combining marks are not regrouped with their base.
+func Reverse(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
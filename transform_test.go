@@ -0,0 +1,68 @@
+package main
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestShout(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"ascii", "Hello, Alice!", "HELLO, ALICE!"},
+		{"accented", "¡Hola, José!", "¡HOLA, JOSÉ!"},
+		{"german", "Hallo, Jürgen!", "HALLO, JÜRGEN!"},
+		{"no letters", "こんにちは、世界！", "こんにちは、世界！"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := Shout(tt.input); result != tt.expected {
+				t.Errorf("Shout(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReverse(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"ascii", "Hello, Alice!", "!ecilA ,olleH"},
+		{"accented", "Hola, José!", "!ésoJ ,aloH"},
+		{"japanese", "こんにちは", "はちにんこ"},
+		{"emoji", "Hi 👋", "👋 iH"},
+		{"single rune", "é", "é"},
+		{"empty", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Reverse(tt.input)
+			if result != tt.expected {
+				t.Errorf("Reverse(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+			if !utf8.ValidString(result) {
+				t.Errorf("Reverse(%q) = %q is not valid UTF-8", tt.input, result)
+			}
+			if back := Reverse(result); back != tt.input {
+				t.Errorf("Reverse(Reverse(%q)) = %q, want the input back", tt.input, back)
+			}
+		})
+	}
+}
+
+func TestShoutReverseCompose(t *testing.T) {
+	expected := "!ÉSOJ ,ALOH"
+	if result := Reverse(Shout("Hola, José!")); result != expected {
+		t.Errorf("Reverse(Shout(...)) = %q, want %q", result, expected)
+	}
+	if result := Shout(Reverse("Hola, José!")); result != expected {
+		t.Errorf("Shout(Reverse(...)) = %q, want %q", result, expected)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultMaxNameLength is the default maximum name length in runes
+const DefaultMaxNameLength = 256
+
+// MaxNameLength is the maximum name length in runes accepted by
+// ValidateName
+var MaxNameLength = DefaultMaxNameLength
+
+// ErrInvalidName is matched, via errors.Is, by every error ValidateName
+// returns
+var ErrInvalidName = errors.New("invalid name")
+
+// NameError describes why ValidateName rejected a name
+type NameError struct {
+	Reason string
+}
+
+// Error returns the reason the name was rejected
+func (e *NameError) Error() string {
+	return e.Reason
+}
+
+// Is reports whether target is ErrInvalidName
+func (e *NameError) Is(target error) bool {
+	return target == ErrInvalidName
+}
+
+// ValidateName reports whether name is safe to greet. It rejects invalid
+// UTF-8, control characters such as newlines and NUL, and names longer than
+// MaxNameLength runes, returning a *NameError. The empty name is valid.
+func ValidateName(name string) error {
+	return validateName(name, MaxNameLength)
+}
+
+// validateName is ValidateName with an explicit maximum length in runes
+func validateName(name string, maxLength int) error {
+	if !utf8.ValidString(name) {
+		return &NameError{Reason: "name is not valid UTF-8"}
+	}
+	for i, r := range []rune(name) {
+		if unicode.IsControl(r) {
+			return &NameError{Reason: fmt.Sprintf("name contains control character %U at position %d", r, i)}
+		}
+	}
+	if n := utf8.RuneCountInString(name); n > maxLength {
+		return &NameError{Reason: fmt.Sprintf("name is %d characters long, maximum is %d", n, maxLength)}
+	}
+	return nil
+}
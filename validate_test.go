@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestValidateName(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr string
+	}{
+		{"empty", "", ""},
+		{"simple", "Alice", ""},
+		{"with spaces", "Mary Jane", ""},
+		{"accented", "José", ""},
+		{"max length ascii", strings.Repeat("a", DefaultMaxNameLength), ""},
+		{"max length multibyte", strings.Repeat("é", DefaultMaxNameLength), ""},
+		{"embedded newline", "Ali\nce", "control character U+000A at position 3"},
+		{"trailing carriage return", "Alice\r", "control character U+000D"},
+		{"null byte", "Ali\x00ce", "control character U+0000"},
+		{"tab", "Ali\tce", "control character U+0009"},
+		{"escape", "\x1b[31mAlice", "control character U+001B at position 0"},
+		{"delete", "Alice\x7f", "control character U+007F"},
+		{"invalid utf-8", "Ali\xffce", "not valid UTF-8"},
+		{"too long", strings.Repeat("a", DefaultMaxNameLength+1), "257 characters long"},
+		{"too long multibyte", strings.Repeat("é", DefaultMaxNameLength+1), "257 characters long"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateName(tt.input)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateName(%q) = %v, want nil", tt.input, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateName(%q) = %v, want error containing %q", tt.input, err, tt.wantErr)
+			}
+			if !errors.Is(err, ErrInvalidName) {
+				t.Errorf("ValidateName(%q) error %v does not match ErrInvalidName", tt.input, err)
+			}
+			var nameErr *NameError
+			if !errors.As(fmt.Errorf("wrapped: %w", err), &nameErr) || nameErr.Reason != err.Error() {
+				t.Errorf("ValidateName(%q) error %v does not unwrap to a *NameError", tt.input, err)
+			}
+		})
+	}
+}
+
+func TestValidateNameMaxLength(t *testing.T) {
+	defer func(n int) { MaxNameLength = n }(MaxNameLength)
+	MaxNameLength = 3
+
+	if err := ValidateName("Bob"); err != nil {
+		t.Errorf("ValidateName(%q) = %v, want nil", "Bob", err)
+	}
+	if err := ValidateName("Alice"); err == nil {
+		t.Errorf("ValidateName(%q) = nil, want error with MaxNameLength %d", "Alice", MaxNameLength)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GreetingVariant selects the phrasing used by HelloVariant
+type GreetingVariant int
+
+// Greeting variants
+const (
+	Standard   GreetingVariant = iota // Hello, Alice!
+	Possessive                        // Hello, Alice's team!
+	Formal                            // Good day, Alice.
+	Casual                            // Hey Alice!
+)
+
+// GreetingVariants returns every greeting variant in declaration order
+func GreetingVariants() []GreetingVariant {
+	return []GreetingVariant{Standard, Possessive, Formal, Casual}
+}
+
+// String returns the variant name in lower case
+func (v GreetingVariant) String() string {
+	switch v {
+	case Standard:
+		return "standard"
+	case Possessive:
+		return "possessive"
+	case Formal:
+		return "formal"
+	case Casual:
+		return "casual"
+	}
+	return fmt.Sprintf("GreetingVariant(%d)", int(v))
+}
+
+// HelloVariant returns the greeting for name phrased as variant. Unknown
+// variants fall back to Standard.
+func HelloVariant(name string, variant GreetingVariant) string {
+	switch variant {
+	case Possessive:
+		if name == "" {
+			return "Hello, team!"
+		}
+		return fmt.Sprintf("Hello, %s team!", possessive(name))
+	case Formal:
+		if name == "" {
+			return "Good day."
+		}
+		return fmt.Sprintf("Good day, %s.", name)
+	case Casual:
+		if name == "" {
+			return "Hey there!"
+		}
+		return fmt.Sprintf("Hey %s!", name)
+	}
+	return Hello(name)
+}
+
+// possessive returns the English possessive form of name
+func possessive(name string) string {
+	if strings.HasSuffix(name, "s") || strings.HasSuffix(name, "S") {
+		return name + "'"
+	}
+	return name + "'s"
+}
@@ -0,0 +1,53 @@
+package main
+
+import "testing"
+
+func TestHelloVariant(t *testing.T) {
+	tests := []struct {
+		variant   GreetingVariant
+		named     string
+		empty     string
+		possName  string
+		possGreet string
+	}{
+		{Standard, "Hello, Alice!", "Hello, World!", "James", "Hello, James!"},
+		{Possessive, "Hello, Alice's team!", "Hello, team!", "James", "Hello, James' team!"},
+		{Formal, "Good day, Alice.", "Good day.", "James", "Good day, James."},
+		{Casual, "Hey Alice!", "Hey there!", "James", "Hey James!"},
+	}
+
+	if len(tests) != len(GreetingVariants()) {
+		t.Fatalf("test table covers %d variants, GreetingVariants() has %d", len(tests), len(GreetingVariants()))
+	}
+	for _, tt := range tests {
+		t.Run(tt.variant.String(), func(t *testing.T) {
+			if result := HelloVariant("Alice", tt.variant); result != tt.named {
+				t.Errorf("HelloVariant(%q, %v) = %q, want %q", "Alice", tt.variant, result, tt.named)
+			}
+			if result := HelloVariant("", tt.variant); result != tt.empty {
+				t.Errorf("HelloVariant(%q, %v) = %q, want %q", "", tt.variant, result, tt.empty)
+			}
+			if result := HelloVariant(tt.possName, tt.variant); result != tt.possGreet {
+				t.Errorf("HelloVariant(%q, %v) = %q, want %q", tt.possName, tt.variant, result, tt.possGreet)
+			}
+		})
+	}
+}
+
+func TestHelloVariantUnknown(t *testing.T) {
+	if result := HelloVariant("Alice", GreetingVariant(99)); result != Hello("Alice") {
+		t.Errorf("HelloVariant with unknown variant = %q, want %q", result, Hello("Alice"))
+	}
+}
+
+func TestGreetingVariantString(t *testing.T) {
+	expected := []string{"standard", "possessive", "formal", "casual"}
+	for i, v := range GreetingVariants() {
+		if v.String() != expected[i] {
+			t.Errorf("GreetingVariants()[%d].String() = %q, want %q", i, v.String(), expected[i])
+		}
+	}
+	if s := GreetingVariant(99).String(); s != "GreetingVariant(99)" {
+		t.Errorf("GreetingVariant(99).String() = %q", s)
+	}
+}
@@ -0,0 +1,17 @@
+package main
+
+import "fmt"
+
+// Build metadata, set at link time with e.g.
+//
+//	go build -ldflags "-X main.Version=1.2.3 -X main.Commit=$(git rev-parse --short HEAD) -X main.BuildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+var (
+	Version   = "dev"
+	Commit    = "none"
+	BuildDate = "unknown"
+)
+
+// VersionString returns the version, commit and build date on one line
+func VersionString() string {
+	return fmt.Sprintf("%s %s (commit %s, built %s)", binaryName, Version, Commit, BuildDate)
+}
@@ -0,0 +1,10 @@
+package main
+
+import "testing"
+
+func TestVersionString(t *testing.T) {
+	expected := "codex-universal dev (commit none, built unknown)"
+	if result := VersionString(); result != expected {
+		t.Errorf("VersionString() = %q, want %q", result, expected)
+	}
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// WebSocket keepalive settings
+const (
+	wsWriteTimeout = 10 * time.Second
+	wsPongTimeout  = 60 * time.Second
+	wsPingInterval = wsPongTimeout * 9 / 10
+)
+
+// wsUpgrader upgrades /ws requests; its default origin check only accepts
+// same-origin browser clients
+var wsUpgrader = websocket.Upgrader{}
+
+// ServeWebSocket upgrades the request to a WebSocket and replies to each
+// text message, read as a name, with its greeting from s.Greeter, in order.
+// Messages longer than maxLineSize bytes close the connection.
+//
+// A connection supports one concurrent reader and one concurrent writer:
+// greetings are written from the read loop and only keepalive pings are sent
+// from another goroutine, through WriteControl, which is safe to call
+// concurrently with the other methods.
+func (s *Server) ServeWebSocket(w http.ResponseWriter, r *http.Request) {
+	conn, err := wsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade has already replied to the client
+		s.Logger.Warn("websocket upgrade", "err", err)
+		return
+	}
+	defer conn.Close()
+
+	conn.SetReadLimit(maxLineSize)
+	conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+	})
+	done := make(chan struct{})
+	defer close(done)
+	go pingWebSocket(conn, done)
+
+	for {
+		typ, msg, err := conn.ReadMessage()
+		if err != nil {
+			if !websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				s.Logger.Warn("websocket read", "err", err)
+			}
+			return
+		}
+		if typ != websocket.TextMessage {
+			conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseUnsupportedData, "text messages only"),
+				time.Now().Add(wsWriteTimeout))
+			return
+		}
+
+		name := string(msg)
+		g, err := TryGreet(s.Greeter, name)
+		if err != nil {
+			s.Logger.Error("greeting failed", "name", name, "err", err)
+			conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseInternalServerErr, "greeting failed"),
+				time.Now().Add(wsWriteTimeout))
+			return
+		}
+		s.recordGreeting(name, defaultLocale)
+		conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(g)); err != nil {
+			s.Logger.Warn("websocket write", "err", err)
+			return
+		}
+	}
+}
+
+// pingWebSocket sends keepalive pings on conn until done is closed
+func pingWebSocket(conn *websocket.Conn, done <-chan struct{}) {
+	ticker := time.NewTicker(wsPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout)); err != nil {
+				return
+			}
+		}
+	}
+}
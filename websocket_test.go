@@ -0,0 +1,120 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// dialWebSocket connects to the /ws endpoint of ts
+func dialWebSocket(t *testing.T, ts *httptest.Server) *websocket.Conn {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(ts.URL, "http") + "/ws"
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("dialing %s: %v", url, err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	return conn
+}
+
+func TestServeWebSocket(t *testing.T) {
+	srv := newTestServer()
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+	conn := dialWebSocket(t, ts)
+
+	for _, name := range []string{"Alice", "Bob"} {
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(name)); err != nil {
+			t.Fatalf("writing %q: %v", name, err)
+		}
+	}
+	for _, want := range []string{"Hello, Alice!", "Hello, Bob!"} {
+		typ, msg, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("reading greeting: %v", err)
+		}
+		if typ != websocket.TextMessage || string(msg) != want {
+			t.Errorf("received %q (type %d), want text %q", msg, typ, want)
+		}
+	}
+
+	err := conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+	if err != nil {
+		t.Fatalf("writing close: %v", err)
+	}
+	_, _, err = conn.ReadMessage()
+	if !websocket.IsCloseError(err, websocket.CloseNormalClosure) {
+		t.Errorf("read after close = %v, want normal closure", err)
+	}
+	if got := srv.History.Count("Alice"); got != 1 {
+		t.Errorf("History.Count(Alice) = %d, want 1", got)
+	}
+}
+
+func TestServeWebSocketEmptyName(t *testing.T) {
+	ts := httptest.NewServer(newTestServer().Handler())
+	defer ts.Close()
+	conn := dialWebSocket(t, ts)
+
+	if err := conn.WriteMessage(websocket.TextMessage, nil); err != nil {
+		t.Fatalf("writing empty name: %v", err)
+	}
+	_, msg, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("reading greeting: %v", err)
+	}
+	if string(msg) != "Hello, World!" {
+		t.Errorf("received %q, want %q", msg, "Hello, World!")
+	}
+}
+
+func TestServeWebSocketRejectsBinary(t *testing.T) {
+	ts := httptest.NewServer(newTestServer().Handler())
+	defer ts.Close()
+	conn := dialWebSocket(t, ts)
+
+	if err := conn.WriteMessage(websocket.BinaryMessage, []byte{0xff}); err != nil {
+		t.Fatalf("writing binary message: %v", err)
+	}
+	_, _, err := conn.ReadMessage()
+	var closeErr *websocket.CloseError
+	if !errors.As(err, &closeErr) || closeErr.Code != websocket.CloseUnsupportedData {
+		t.Errorf("read after binary message = %v, want unsupported data close", err)
+	}
+}
+
+func TestServeWebSocketReadLimit(t *testing.T) {
+	ts := httptest.NewServer(newTestServer().Handler())
+	defer ts.Close()
+	conn := dialWebSocket(t, ts)
+
+	if err := conn.WriteMessage(websocket.TextMessage, make([]byte, maxLineSize+1)); err != nil {
+		t.Fatalf("writing oversized message: %v", err)
+	}
+	_, _, err := conn.ReadMessage()
+	var closeErr *websocket.CloseError
+	if !errors.As(err, &closeErr) || closeErr.Code != websocket.CloseMessageTooBig {
+		t.Errorf("read after oversized message = %v, want message too big close", err)
+	}
+}
+
+func TestServeWebSocketRateLimit(t *testing.T) {
+	srv := newTestServer()
+	srv.RateLimiter = NewRateLimiter(1, 1)
+	ts := httptest.NewServer(srv.Handler())
+	defer ts.Close()
+
+	dialWebSocket(t, ts)
+	url := "ws" + strings.TrimPrefix(ts.URL, "http") + "/ws"
+	_, resp, err := websocket.DefaultDialer.Dial(url, nil)
+	if err == nil || resp == nil || resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("second dial = %v, want 429 Too Many Requests", err)
+	}
+}
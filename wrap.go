@@ -0,0 +1,47 @@
+package main
+
+import "strings"
+
+// WrapGreeting wraps s into lines of at most width runes, breaking between
+// words and collapsing runs of whitespace. Words longer than width are split
+// on rune boundaries, so multibyte characters are never cut. A width of
+// zero or less disables wrapping.
+func WrapGreeting(s string, width int) string {
+	if width <= 0 {
+		return s
+	}
+	var lines []string
+	var line []rune
+	flush := func() {
+		if len(line) > 0 {
+			lines = append(lines, string(line))
+			line = nil
+		}
+	}
+	for _, word := range strings.Fields(s) {
+		w := []rune(word)
+		for len(w) > width {
+			flush()
+			lines = append(lines, string(w[:width]))
+			w = w[width:]
+		}
+		if len(line) > 0 && len(line)+1+len(w) > width {
+			flush()
+		}
+		if len(line) > 0 {
+			line = append(line, ' ')
+		}
+		line = append(line, w...)
+	}
+	flush()
+	return strings.Join(lines, "\n")
+}
+
+// indentLines prefixes each line of s with n spaces
+func indentLines(s string, n int) string {
+	if n <= 0 {
+		return s
+	}
+	prefix := strings.Repeat(" ", n)
+	return prefix + strings.ReplaceAll(s, "\n", "\n"+prefix)
+}
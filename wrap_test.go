@@ -0,0 +1,67 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestWrapGreeting(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		width    int
+		expected string
+	}{
+		{"disabled", "Hello, Alice!", 0, "Hello, Alice!"},
+		{"negative disables", "Hello, Alice!", -1, "Hello, Alice!"},
+		{"fits", "Hello, Alice!", 20, "Hello, Alice!"},
+		{"exact fit", "Hello, Alice!", 13, "Hello, Alice!"},
+		{"word break", "Hello, Alice!", 8, "Hello,\nAlice!"},
+		{"several words", "Good morning, Mary Jane Smith!", 12, "Good\nmorning,\nMary Jane\nSmith!"},
+		{"long word split", "Hello, Bartholomew!", 5, "Hello\n,\nBarth\nolome\nw!"},
+		{"multibyte", "¡Hola, José Ñúñez!", 6, "¡Hola,\nJosé\nÑúñez!"},
+		{"multibyte split", "こんにちは、世界！", 4, "こんにち\nは、世界\n！"},
+		{"emoji split", "👋👋👋👋👋", 2, "👋👋\n👋👋\n👋"},
+		{"width one", "Héé", 1, "H\né\né"},
+		{"collapses whitespace", "Hello,   World!", 20, "Hello, World!"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := WrapGreeting(tt.input, tt.width)
+			if result != tt.expected {
+				t.Errorf("WrapGreeting(%q, %d) = %q, want %q", tt.input, tt.width, result, tt.expected)
+			}
+			if !utf8.ValidString(result) {
+				t.Errorf("WrapGreeting(%q, %d) split a character: %q", tt.input, tt.width, result)
+			}
+			if tt.width <= 0 {
+				return
+			}
+			for _, line := range strings.Split(result, "\n") {
+				if n := utf8.RuneCountInString(line); n > tt.width {
+					t.Errorf("WrapGreeting(%q, %d) line %q is %d runes wide", tt.input, tt.width, line, n)
+				}
+			}
+		})
+	}
+}
+
+func TestIndentLines(t *testing.T) {
+	tests := []struct {
+		input    string
+		n        int
+		expected string
+	}{
+		{"Hello, Alice!", 0, "Hello, Alice!"},
+		{"Hello, Alice!", 2, "  Hello, Alice!"},
+		{"Hello,\nAlice!", 3, "   Hello,\n   Alice!"},
+	}
+
+	for _, tt := range tests {
+		if result := indentLines(tt.input, tt.n); result != tt.expected {
+			t.Errorf("indentLines(%q, %d) = %q, want %q", tt.input, tt.n, result, tt.expected)
+		}
+	}
+}